@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"splitwise-clone/internal/config"
+	"splitwise-clone/internal/domain/auth"
+	"splitwise-clone/internal/grpcapi"
+	"splitwise-clone/internal/grpcapi/authpb"
+	"splitwise-clone/internal/logger"
+	"splitwise-clone/internal/mail"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+)
+
+// grpcLoginRateLimit and grpcLoginRateLimitWindow bound the in-memory login
+// attempt recorder built for the gRPC auth service, mirroring the REST
+// router's own login rate limit.
+const (
+	grpcLoginRateLimit       = 5
+	grpcLoginRateLimitWindow = 15 * time.Minute
+)
+
+// GRPCServer represents the gRPC server
+type GRPCServer struct {
+	server *grpc.Server
+	addr   string
+}
+
+// NewGRPCServer creates a new gRPC server instance. It builds its own
+// auth.Service from db and cfg, exactly as NewHTTPServer's router does, so
+// both transports run the same business logic against the same database.
+func NewGRPCServer(cfg *config.Config, db *pgxpool.Pool) *GRPCServer {
+	authCommandRepo := auth.NewCommandRepository(db)
+	authQueryRepo := auth.NewQueryRepository(db)
+	refreshTokenRepo := auth.NewRefreshTokenRepository(db)
+	federatedIdentityRepo := auth.NewFederatedIdentityRepository(db)
+	emailVerificationRepo := auth.NewEmailVerificationRepository(db)
+	passwordResetRepo := auth.NewPasswordResetRepository(db)
+	txManager := auth.NewTxManager(db)
+	loginAttempts := auth.NewInMemoryLoginAttemptRecorder(grpcLoginRateLimit, grpcLoginRateLimitWindow)
+
+	authService := auth.NewService(authCommandRepo, authQueryRepo, refreshTokenRepo, federatedIdentityRepo, emailVerificationRepo, passwordResetRepo, txManager, cfg.ToAuthConfig(), loginAttempts, mail.NewFromEnv())
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcapi.TraceIDUnaryInterceptor,
+			grpcapi.RecoveryUnaryInterceptor,
+			grpcapi.LoggingUnaryInterceptor,
+			grpcapi.AuthUnaryInterceptor(authService),
+		),
+	)
+
+	authpb.RegisterAuthServiceServer(srv, grpcapi.NewAuthServer(authService))
+
+	return &GRPCServer{
+		server: srv,
+		addr:   fmt.Sprintf(":%d", cfg.Server.GRPCPort),
+	}
+}
+
+// Start starts the gRPC server
+func (s *GRPCServer) Start() error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("grpc: failed to listen on %s: %w", s.addr, err)
+	}
+
+	logger.L().Info("Starting gRPC server", "addr", s.addr)
+	return s.server.Serve(lis)
+}
+
+// Shutdown gracefully shuts down the gRPC server
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	logger.L().Info("Shutting down gRPC server")
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
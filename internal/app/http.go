@@ -6,10 +6,11 @@ import (
 	"net/http"
 	"time"
 
+	"splitwise-clone/internal/config"
 	"splitwise-clone/internal/httpapi/router"
+	"splitwise-clone/internal/logger"
 
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/rs/zerolog/log"
 )
 
 // HTTPServer represents the HTTP server
@@ -19,8 +20,10 @@ type HTTPServer struct {
 }
 
 // NewHTTPServer creates a new HTTP server instance
-func NewHTTPServer(port int, db *pgxpool.Pool) *HTTPServer {
-	r := router.NewRouter(db)
+func NewHTTPServer(cfg *config.Config, db *pgxpool.Pool) *HTTPServer {
+	r := router.NewRouter(db, cfg.ToAuthConfig())
+
+	port := cfg.Server.Port
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
@@ -38,12 +41,12 @@ func NewHTTPServer(port int, db *pgxpool.Pool) *HTTPServer {
 
 // Start starts the HTTP server
 func (s *HTTPServer) Start() error {
-	log.Info().Str("addr", s.server.Addr).Msg("Starting HTTP server")
+	logger.L().Info("Starting HTTP server", "addr", s.server.Addr)
 	return s.server.ListenAndServe()
 }
 
 // Shutdown gracefully shuts down the HTTP server
 func (s *HTTPServer) Shutdown(ctx context.Context) error {
-	log.Info().Msg("Shutting down HTTP server")
+	logger.L().Info("Shutting down HTTP server")
 	return s.server.Shutdown(ctx)
 }
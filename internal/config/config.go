@@ -0,0 +1,98 @@
+// Package config loads application configuration from a YAML file, applying
+// sane defaults for anything the file omits.
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"splitwise-clone/internal/domain/auth"
+
+	"github.com/spf13/viper"
+)
+
+// Config is the root application configuration.
+type Config struct {
+	Server ServerConfig
+	Auth   AuthConfig
+}
+
+// ServerConfig holds HTTP and gRPC server settings.
+type ServerConfig struct {
+	Port     int
+	GRPCPort int
+}
+
+// AuthConfig mirrors auth.Config in a YAML-friendly shape.
+type AuthConfig struct {
+	BcryptCost               int
+	AccessTokenTTL           time.Duration
+	Issuer                   string
+	SigningKeys              []SigningKeyConfig
+	EmailVerificationTTL     time.Duration
+	PasswordResetTTL         time.Duration
+	RequireEmailVerification bool
+	AppBaseURL               string
+}
+
+// SigningKeyConfig is a single named signing key, as loaded from config. The
+// first entry in AuthConfig.SigningKeys is the primary key used to sign new
+// access tokens; any others are retained only to validate tokens signed
+// before a rotation.
+type SigningKeyConfig struct {
+	KID    string
+	Secret string
+}
+
+// Load reads configuration from the YAML file at path, filling in defaults
+// for anything left unset.
+func Load(path string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+
+	v.SetDefault("server.port", 8080)
+	v.SetDefault("server.grpcport", 9090)
+	v.SetDefault("auth.bcryptcost", 12)
+	v.SetDefault("auth.accesstokenttl", 15*time.Minute)
+	v.SetDefault("auth.issuer", "splitwise-clone")
+	v.SetDefault("auth.emailverificationttl", 24*time.Hour)
+	v.SetDefault("auth.passwordresetttl", time.Hour)
+	v.SetDefault("auth.requireemailverification", false)
+	v.SetDefault("auth.appbaseurl", "http://localhost:8080")
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("config: failed to read %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("config: failed to parse %s: %w", path, err)
+	}
+
+	if len(cfg.Auth.SigningKeys) == 0 {
+		return nil, fmt.Errorf("config: auth.signingkeys must contain at least one signing key")
+	}
+
+	return &cfg, nil
+}
+
+// ToAuthConfig converts the loaded auth settings into the auth package's own
+// Config type, which is what auth.NewService expects.
+func (c *Config) ToAuthConfig() auth.Config {
+	keys := make([]auth.SigningKey, len(c.Auth.SigningKeys))
+	for i, k := range c.Auth.SigningKeys {
+		keys[i] = auth.SigningKey{KID: k.KID, Secret: k.Secret}
+	}
+
+	return auth.Config{
+		BcryptCost:               c.Auth.BcryptCost,
+		AccessTokenTTL:           c.Auth.AccessTokenTTL,
+		Issuer:                   c.Auth.Issuer,
+		SigningKeys:              keys,
+		EmailVerificationTTL:     c.Auth.EmailVerificationTTL,
+		PasswordResetTTL:         c.Auth.PasswordResetTTL,
+		RequireEmailVerification: c.Auth.RequireEmailVerification,
+		AppBaseURL:               c.Auth.AppBaseURL,
+	}
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"splitwise-clone/internal/domain/user"
+	"splitwise-clone/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuthQueryRepository defines the read side of authentication-related
+// database operations, kept separate from AuthCommandRepository so it can
+// later be backed by a read replica or cache.
+type AuthQueryRepository interface {
+	GetUserByEmail(ctx context.Context, email string) (*user.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error)
+	UserExistsByEmail(ctx context.Context, email string) (bool, error)
+}
+
+// queryRepository implements AuthQueryRepository
+type queryRepository struct {
+	db pgxPool
+}
+
+// NewQueryRepository creates a new auth query repository instance
+func NewQueryRepository(db *pgxpool.Pool) AuthQueryRepository {
+	return &queryRepository{
+		db: db,
+	}
+}
+
+// GetUserByEmail retrieves a user by their email address
+func (r *queryRepository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	query := `
+		SELECT id, first_name, last_name, date_of_birth, email, password_hash, phone_number, email_verified_at, created_at, updated_at, deleted_at
+		FROM users
+		WHERE email = $1 AND deleted_at IS NULL
+	`
+
+	logger.L().DebugContext(ctx, "Executing GetUserByEmail query", "email", email)
+
+	var u user.User
+	err := r.db.QueryRow(ctx, query, email).Scan(
+		&u.ID,
+		&u.FirstName,
+		&u.LastName,
+		&u.DateOfBirth,
+		&u.Email,
+		&u.PasswordHash,
+		&u.PhoneNumber,
+		&u.EmailVerifiedAt,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+		&u.DeletedAt,
+	)
+
+	if err != nil {
+		logger.L().DebugContext(ctx, "User not found or query failed", "error", err, "email", email)
+		return nil, err
+	}
+
+	logger.L().DebugContext(ctx, "User retrieved successfully", "user_id", u.ID.String())
+	return &u, nil
+}
+
+// GetUserByID retrieves a user by their ID
+func (r *queryRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	query := `
+		SELECT id, first_name, last_name, date_of_birth, email, password_hash, phone_number, email_verified_at, created_at, updated_at, deleted_at
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	logger.L().DebugContext(ctx, "Executing GetUserByID query", "user_id", id.String())
+
+	var u user.User
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&u.ID,
+		&u.FirstName,
+		&u.LastName,
+		&u.DateOfBirth,
+		&u.Email,
+		&u.PasswordHash,
+		&u.PhoneNumber,
+		&u.EmailVerifiedAt,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+		&u.DeletedAt,
+	)
+	if err != nil {
+		logger.L().DebugContext(ctx, "User not found or query failed", "error", err, "user_id", id.String())
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+// UserExistsByEmail checks if a user with the given email already exists
+func (r *queryRepository) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND deleted_at IS NULL)`
+
+	logger.L().DebugContext(ctx, "Checking if user exists", "email", email)
+
+	var exists bool
+	err := r.db.QueryRow(ctx, query, email).Scan(&exists)
+	if err != nil {
+		logger.L().ErrorContext(ctx, "Failed to check user existence", "error", err, "email", email)
+		return false, err
+	}
+
+	logger.L().DebugContext(ctx, "User existence check completed", "email", email, "exists", exists)
+	return exists, nil
+}
@@ -5,42 +5,51 @@ import (
 	"splitwise-clone/internal/domain/user"
 	"splitwise-clone/internal/logger"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// Repository defines the interface for authentication-related database operations
-type Repository interface {
+// AuthCommandRepository defines the write side of authentication-related
+// database operations. Splitting writes from AuthQueryRepository lets the
+// query side later be pointed at a replica pool or cache without touching
+// this interface.
+type AuthCommandRepository interface {
 	CreateUser(ctx context.Context, params user.CreateUserParams) (*user.User, error)
-	GetUserByEmail(ctx context.Context, email string) (*user.User, error)
-	UserExistsByEmail(ctx context.Context, email string) (bool, error)
+	SoftDeleteUser(ctx context.Context, id uuid.UUID) error
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, id uuid.UUID) error
 }
 
-// repository implements the Repository interface
-type repository struct {
-	db *pgxpool.Pool
+// commandRepository implements AuthCommandRepository. Its methods honor a
+// transaction placed in ctx by TxManager.WithTransaction, falling back to
+// the pool when there is none.
+type commandRepository struct {
+	db pgxPool
 }
 
-// NewRepository creates a new auth repository instance
-func NewRepository(db *pgxpool.Pool) Repository {
-	return &repository{
+// NewCommandRepository creates a new auth command repository instance
+func NewCommandRepository(db *pgxpool.Pool) AuthCommandRepository {
+	return &commandRepository{
 		db: db,
 	}
 }
 
-// CreateUser inserts a new user into the database
-func (r *repository) CreateUser(ctx context.Context, params user.CreateUserParams) (*user.User, error) {
-	log := logger.FromContext(ctx)
+func (r *commandRepository) querier(ctx context.Context) querier {
+	return querierFromContext(ctx, r.db)
+}
 
+// CreateUser inserts a new user into the database
+func (r *commandRepository) CreateUser(ctx context.Context, params user.CreateUserParams) (*user.User, error) {
 	query := `
 		INSERT INTO users (first_name, last_name, date_of_birth, email, password_hash, phone_number)
 		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, first_name, last_name, date_of_birth, email, password_hash, phone_number, created_at, updated_at, deleted_at
+		RETURNING id, first_name, last_name, date_of_birth, email, password_hash, phone_number, email_verified_at, created_at, updated_at, deleted_at
 	`
 
-	log.Debug().Str("email", params.Email).Msg("Executing CreateUser query")
+	logger.L().DebugContext(ctx, "Executing CreateUser query", "email", params.Email)
 
 	var u user.User
-	err := r.db.QueryRow(ctx, query,
+	err := r.querier(ctx).QueryRow(ctx, query,
 		params.FirstName,
 		params.LastName,
 		params.DateOfBirth,
@@ -55,70 +64,55 @@ func (r *repository) CreateUser(ctx context.Context, params user.CreateUserParam
 		&u.Email,
 		&u.PasswordHash,
 		&u.PhoneNumber,
+		&u.EmailVerifiedAt,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 		&u.DeletedAt,
 	)
 
 	if err != nil {
-		log.Error().Err(err).Str("email", params.Email).Msg("Failed to insert user into database")
+		logger.L().ErrorContext(ctx, "Failed to insert user into database", "error", err, "email", params.Email)
 		return nil, err
 	}
 
-	log.Debug().Str("user_id", u.ID.String()).Msg("User inserted successfully")
+	logger.L().DebugContext(ctx, "User inserted successfully", "user_id", u.ID.String())
 	return &u, nil
 }
 
-// GetUserByEmail retrieves a user by their email address
-func (r *repository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
-	log := logger.FromContext(ctx)
+// SoftDeleteUser marks a user as deleted without removing the row
+func (r *commandRepository) SoftDeleteUser(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE users SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
 
-	query := `
-		SELECT id, first_name, last_name, date_of_birth, email, password_hash, phone_number, created_at, updated_at, deleted_at
-		FROM users
-		WHERE email = $1 AND deleted_at IS NULL
-	`
+	if _, err := r.querier(ctx).Exec(ctx, query, id); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to soft delete user", "error", err, "user_id", id.String())
+		return err
+	}
 
-	log.Debug().Str("email", email).Msg("Executing GetUserByEmail query")
+	return nil
+}
 
-	var u user.User
-	err := r.db.QueryRow(ctx, query, email).Scan(
-		&u.ID,
-		&u.FirstName,
-		&u.LastName,
-		&u.DateOfBirth,
-		&u.Email,
-		&u.PasswordHash,
-		&u.PhoneNumber,
-		&u.CreatedAt,
-		&u.UpdatedAt,
-		&u.DeletedAt,
-	)
+// UpdatePasswordHash replaces a user's stored password hash, e.g. after a
+// password reset
+func (r *commandRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $2, updated_at = now() WHERE id = $1 AND deleted_at IS NULL`
 
-	if err != nil {
-		log.Debug().Err(err).Str("email", email).Msg("User not found or query failed")
-		return nil, err
+	if _, err := r.querier(ctx).Exec(ctx, query, id, passwordHash); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to update password hash", "error", err, "user_id", id.String())
+		return err
 	}
 
-	log.Debug().Str("user_id", u.ID.String()).Msg("User retrieved successfully")
-	return &u, nil
+	return nil
 }
 
-// UserExistsByEmail checks if a user with the given email already exists
-func (r *repository) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
-	log := logger.FromContext(ctx)
+// MarkEmailVerified records that a user has confirmed control of their
+// email address
+func (r *commandRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE users SET email_verified_at = now(), updated_at = now() WHERE id = $1 AND deleted_at IS NULL`
 
-	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND deleted_at IS NULL)`
-
-	log.Debug().Str("email", email).Msg("Checking if user exists")
-
-	var exists bool
-	err := r.db.QueryRow(ctx, query, email).Scan(&exists)
-	if err != nil {
-		log.Error().Err(err).Str("email", email).Msg("Failed to check user existence")
-		return false, err
+	if _, err := r.querier(ctx).Exec(ctx, query, id); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to mark email verified", "error", err, "user_id", id.String())
+		return err
 	}
 
-	log.Debug().Str("email", email).Bool("exists", exists).Msg("User existence check completed")
-	return exists, nil
+	return nil
 }
@@ -2,29 +2,41 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
-	"os"
+	"fmt"
 	"splitwise-clone/internal/domain/user"
 	"splitwise-clone/internal/logger"
+	"splitwise-clone/internal/mail"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
 var (
-	ErrUserAlreadyExists = errors.New("user with this email already exists")
-	ErrInvalidPassword   = errors.New("invalid password")
-	ErrUserNotFound      = errors.New("user not found")
-	ErrTokenGeneration   = errors.New("failed to generate token")
+	ErrUserAlreadyExists        = errors.New("user with this email already exists")
+	ErrInvalidPassword          = errors.New("invalid password")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrTokenGeneration          = errors.New("failed to generate token")
+	ErrInvalidRefreshToken      = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused       = errors.New("refresh token reuse detected")
+	ErrInvalidToken             = errors.New("invalid or expired access token")
+	ErrTokenRevoked             = errors.New("access token has been revoked")
+	ErrEmailNotVerified         = errors.New("email address has not been verified")
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrInvalidResetToken        = errors.New("invalid or expired password reset token")
 )
 
-// JWT configuration
-var (
-	jwtSecret = []byte(getJWTSecret())
-	jwtExpiry = 24 * time.Hour // Token expires in 24 hours
-)
+// refreshTokenTTL controls how long refresh tokens live. Unlike the access
+// token policy, this isn't operator-tunable yet, so it stays a package
+// constant rather than part of Config.
+const refreshTokenTTL = 30 * 24 * time.Hour
 
 // Claims represents the JWT claims
 type Claims struct {
@@ -35,7 +47,30 @@ type Claims struct {
 // Service defines the interface for authentication business logic
 type Service interface {
 	SignUp(ctx context.Context, params SignUpParams) (*user.User, error)
-	Login(ctx context.Context, email, password string) (string, error)
+	Login(ctx context.Context, email, password string) (*LoginResult, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	LoginWithFederatedIdentity(ctx context.Context, identity FederatedIdentity) (*LoginResult, error)
+	ValidateToken(ctx context.Context, tokenString string) (*Claims, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error)
+	SendVerificationEmail(ctx context.Context, email string) error
+	ConfirmEmail(ctx context.Context, token string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+// TokenPair bundles an access token with its accompanying refresh token
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// LoginResult is returned on successful authentication
+type LoginResult struct {
+	TokenPair
+	User *user.User
 }
 
 // SignUpParams contains parameters for user registration
@@ -50,13 +85,33 @@ type SignUpParams struct {
 
 // service implements the Service interface
 type service struct {
-	repo Repository
+	cmdRepo               AuthCommandRepository
+	queryRepo             AuthQueryRepository
+	refreshRepo           RefreshTokenRepository
+	federatedRepo         FederatedIdentityRepository
+	emailVerificationRepo EmailVerificationRepository
+	passwordResetRepo     PasswordResetRepository
+	txManager             Transactor
+	cfg                   Config
+	attempts              LoginAttemptRecorder
+	mailer                mail.Mailer
 }
 
-// NewService creates a new auth service instance
-func NewService(repo Repository) Service {
+// NewService creates a new auth service instance. cfg must carry at least
+// one signing key; the first is used to sign new access tokens, and the
+// rest are only consulted to validate tokens from before a rotation.
+func NewService(cmdRepo AuthCommandRepository, queryRepo AuthQueryRepository, refreshRepo RefreshTokenRepository, federatedRepo FederatedIdentityRepository, emailVerificationRepo EmailVerificationRepository, passwordResetRepo PasswordResetRepository, txManager Transactor, cfg Config, attempts LoginAttemptRecorder, mailer mail.Mailer) Service {
 	return &service{
-		repo: repo,
+		cmdRepo:               cmdRepo,
+		queryRepo:             queryRepo,
+		refreshRepo:           refreshRepo,
+		federatedRepo:         federatedRepo,
+		emailVerificationRepo: emailVerificationRepo,
+		passwordResetRepo:     passwordResetRepo,
+		txManager:             txManager,
+		cfg:                   cfg,
+		attempts:              attempts,
+		mailer:                mailer,
 	}
 }
 
@@ -64,24 +119,24 @@ func NewService(repo Repository) Service {
 func (s *service) SignUp(ctx context.Context, params SignUpParams) (*user.User, error) {
 	log := logger.FromContext(ctx)
 
-	log.Debug().Str("email", params.Email).Msg("Starting user signup process")
+	log.Debug("Starting user signup process", "email", params.Email)
 
 	// Check if user already exists
-	exists, err := s.repo.UserExistsByEmail(ctx, params.Email)
+	exists, err := s.queryRepo.UserExistsByEmail(ctx, params.Email)
 	if err != nil {
-		log.Error().Err(err).Str("email", params.Email).Msg("Failed to check if user exists")
+		log.Error("Failed to check if user exists", "error", err, "email", params.Email)
 		return nil, err
 	}
 	if exists {
-		log.Debug().Str("email", params.Email).Msg("User already exists")
+		log.Debug("User already exists", "email", params.Email)
 		return nil, ErrUserAlreadyExists
 	}
 
 	// Hash the password using bcrypt
-	log.Debug().Msg("Hashing password")
-	hashedPassword, err := hashPassword(params.Password)
+	log.Debug("Hashing password")
+	hashedPassword, err := s.hashPassword(params.Password)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to hash password")
+		log.Error("Failed to hash password", "error", err)
 		return nil, err
 	}
 
@@ -95,60 +150,512 @@ func (s *service) SignUp(ctx context.Context, params SignUpParams) (*user.User,
 		DateOfBirth:  params.DateOfBirth,
 	}
 
-	log.Debug().Str("email", params.Email).Msg("Creating user in database")
-	newUser, err := s.repo.CreateUser(ctx, createParams)
+	// The user row and its verification token are created in the same
+	// transaction, so a failure persisting the token rolls back the
+	// signup rather than leaving a user who can never verify their email.
+	var newUser *user.User
+	var rawVerificationToken string
+	var verificationExpiresAt time.Time
+
+	log.Debug("Creating user in database", "email", params.Email)
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		newUser, err = s.cmdRepo.CreateUser(ctx, createParams)
+		if err != nil {
+			return err
+		}
+
+		rawVerificationToken, verificationExpiresAt, err = s.createVerificationToken(ctx, newUser.ID)
+		return err
+	})
 	if err != nil {
-		log.Error().Err(err).Str("email", params.Email).Msg("Failed to create user in database")
+		log.Error("Failed to create user in database", "error", err, "email", params.Email)
 		return nil, err
 	}
 
-	log.Info().
-		Str("user_id", newUser.ID.String()).
-		Str("email", newUser.Email).
-		Msg("User signup completed successfully")
+	log.Info("User signup completed successfully", "user_id", newUser.ID.String(), "email", newUser.Email)
+
+	if err := s.sendVerificationEmail(ctx, newUser, rawVerificationToken, verificationExpiresAt); err != nil {
+		// Signup has already succeeded; the user can request another
+		// verification email later, so this is logged rather than failed.
+		log.Error("Failed to send verification email after signup", "error", err, "user_id", newUser.ID.String())
+	}
 
 	return newUser, nil
 }
 
 // Login handles user authentication
-func (s *service) Login(ctx context.Context, email, password string) (string, error) {
+func (s *service) Login(ctx context.Context, email, password string) (*LoginResult, error) {
 	log := logger.FromContext(ctx)
 
-	log.Debug().Str("email", email).Msg("Starting user login process")
+	log.Debug("Starting user login process", "email", email)
 
-	// Check if user exists
-	user, err := s.repo.GetUserByEmail(ctx, email)
+	allowed, retryAfter, err := s.attempts.Allow(ctx, email)
 	if err != nil {
-		log.Error().Err(err).Str("email", email).Msg("Failed to get user by email")
-		return "", err
+		log.Error("Failed to check login attempt budget", "error", err, "email", email)
+		return nil, err
+	}
+	if !allowed {
+		log.Warn("Login blocked: too many failed attempts", "email", email, "retry_after", retryAfter)
+		return nil, &ErrAccountLocked{RetryAfter: retryAfter}
 	}
-	if user == nil {
-		log.Debug().Str("email", email).Msg("User not found")
-		return "", ErrUserNotFound
+
+	// Check if user exists
+	u, err := s.queryRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Error("Failed to get user by email", "error", err, "email", email)
+			return nil, err
+		}
+		log.Debug("User not found", "email", email)
+		_ = s.attempts.RecordFailure(ctx, email)
+		return nil, ErrUserNotFound
 	}
 
 	// Verify Password
-	err = verifyPassword(user.PasswordHash, password)
+	if err := verifyPassword(u.PasswordHash, password); err != nil {
+		log.Error("Failed to verify password", "error", err, "email", email)
+		_ = s.attempts.RecordFailure(ctx, email)
+		return nil, ErrInvalidPassword
+	}
+
+	if s.cfg.RequireEmailVerification && u.EmailVerifiedAt == nil {
+		log.Debug("Login blocked: email not verified", "email", email)
+		return nil, ErrEmailNotVerified
+	}
+
+	log.Info("User authenticated successfully", "email", email)
+
+	_ = s.attempts.RecordSuccess(ctx, email)
+
+	pair, err := s.issueTokenPair(ctx, u.ID)
+	if err != nil {
+		log.Error("Failed to issue token pair", "error", err, "email", email)
+		return nil, err
+	}
+
+	return &LoginResult{TokenPair: *pair, User: u}, nil
+}
+
+// RefreshToken verifies a presented refresh token and rotates it, returning
+// a new access/refresh pair. Presenting a token that was already revoked
+// indicates theft or replay, so the entire token family is revoked.
+func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	log := logger.FromContext(ctx)
+
+	hashed := hashRefreshToken(refreshToken)
+
+	existing, err := s.refreshRepo.GetRefreshTokenByHash(ctx, hashed)
+	if err != nil {
+		log.Debug("Refresh token not found", "error", err)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if existing.RevokedAt != nil {
+		log.Warn("Revoked refresh token reused; revoking token family", "user_id", existing.UserID.String())
+		if revokeErr := s.refreshRepo.RevokeAllForUser(ctx, existing.UserID); revokeErr != nil {
+			log.Error("Failed to revoke refresh token family", "error", revokeErr, "user_id", existing.UserID.String())
+			return nil, revokeErr
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if time.Now().After(existing.ExpiresAt) {
+		log.Debug("Refresh token expired", "user_id", existing.UserID.String())
+		return nil, ErrInvalidRefreshToken
+	}
+
+	pair, newRecordID, err := s.issueTokenPairWithRecord(ctx, existing.UserID)
+	if err != nil {
+		log.Error("Failed to issue token pair on refresh", "error", err, "user_id", existing.UserID.String())
+		return nil, err
+	}
+
+	if err := s.refreshRepo.RevokeRefreshToken(ctx, existing.ID, &newRecordID); err != nil {
+		log.Error("Failed to revoke rotated refresh token", "error", err, "refresh_token_id", existing.ID.String())
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+// Logout revokes the presented refresh token so it can no longer be used
+func (s *service) Logout(ctx context.Context, refreshToken string) error {
+	log := logger.FromContext(ctx)
+
+	hashed := hashRefreshToken(refreshToken)
+
+	existing, err := s.refreshRepo.GetRefreshTokenByHash(ctx, hashed)
+	if err != nil {
+		log.Debug("Refresh token not found on logout", "error", err)
+		return ErrInvalidRefreshToken
+	}
+
+	return s.refreshRepo.RevokeRefreshToken(ctx, existing.ID, nil)
+}
+
+// LoginWithFederatedIdentity authenticates a user via an external identity
+// provider, auto-provisioning a local account on first login. Accounts
+// created this way have no password set, since authentication is delegated
+// to the federated provider.
+func (s *service) LoginWithFederatedIdentity(ctx context.Context, identity FederatedIdentity) (*LoginResult, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debug("Starting federated login", "provider", identity.Provider)
+
+	u, err := s.federatedRepo.GetUserByFederatedIdentity(ctx, identity.Provider, identity.Subject)
 	if err != nil {
-		log.Error().Err(err).Str("email", email).Msg("Failed to verify password")
-		return "", ErrInvalidPassword
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Error("Failed to look up federated identity", "error", err, "provider", identity.Provider)
+			return nil, err
+		}
+
+		u, err = s.provisionFederatedUser(ctx, identity)
+		if err != nil {
+			log.Error("Failed to auto-provision federated user", "error", err, "provider", identity.Provider)
+			return nil, err
+		}
 	}
 
-	log.Info().Str("email", email).Msg("User authenticated successfully")
+	log.Info("User authenticated via federated identity", "user_id", u.ID.String(), "provider", identity.Provider)
+
+	pair, err := s.issueTokenPair(ctx, u.ID)
+	if err != nil {
+		log.Error("Failed to issue token pair", "error", err, "user_id", u.ID.String())
+		return nil, err
+	}
+
+	return &LoginResult{TokenPair: *pair, User: u}, nil
+}
+
+// provisionFederatedUser links the external identity that signed the caller
+// in to a local account, creating one if this is the first time this email
+// has signed in at all. If an account with this email already exists (e.g.
+// from a password signup, or a different provider sharing the same
+// verified email), the identity is linked to it instead of attempting to
+// create a second user row, which would collide on the unique email
+// constraint. Any create and the link run inside a single transaction so a
+// failure linking the identity rolls back the user creation rather than
+// leaving an orphaned account; the email lookup goes through the query
+// repository, which reads from the pool rather than that transaction.
+func (s *service) provisionFederatedUser(ctx context.Context, identity FederatedIdentity) (*user.User, error) {
+	var resultUser *user.User
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.queryRepo.GetUserByEmail(ctx, identity.Email)
+		if err == nil {
+			resultUser = existing
+			return s.federatedRepo.LinkFederatedIdentity(ctx, resultUser.ID, identity.Provider, identity.Subject)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		resultUser, err = s.cmdRepo.CreateUser(ctx, user.CreateUserParams{
+			FirstName:    identity.FirstName,
+			LastName:     identity.LastName,
+			Email:        identity.Email,
+			PasswordHash: "", // no password set; authentication is delegated to the provider
+		})
+		if err != nil {
+			return err
+		}
 
-	// Generate JWT Token
-	token, err := generateJWTToken(user.ID)
+		return s.federatedRepo.LinkFederatedIdentity(ctx, resultUser.ID, identity.Provider, identity.Subject)
+	})
 	if err != nil {
-		log.Error().Err(err).Str("email", email).Msg("Failed to generate JWT token")
+		return nil, err
+	}
+
+	return resultUser, nil
+}
+
+// ValidateToken parses and verifies an access token, returning its claims.
+// It also checks the refresh token family sharing the access token's jti,
+// so logging out or rotating past a reused refresh token revokes the
+// access token as well.
+func (s *service) ValidateToken(ctx context.Context, tokenString string) (*Claims, error) {
+	log := logger.FromContext(ctx)
+
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		key, ok := s.cfg.keyByKID(kid)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		return []byte(key.Secret), nil
+	})
+	if err != nil || !token.Valid {
+		log.Debug("Access token failed validation", "error", err)
+		return nil, ErrInvalidToken
+	}
+
+	if claims.ID != "" {
+		jti, err := uuid.Parse(claims.ID)
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+
+		record, err := s.refreshRepo.GetRefreshTokenByID(ctx, jti)
+		if err == nil && record.RevokedAt != nil {
+			log.Debug("Access token's refresh family has been revoked", "user_id", claims.UserID.String())
+			return nil, ErrTokenRevoked
+		}
+	}
+
+	return claims, nil
+}
+
+// GetUserByID retrieves a user's profile by ID, used by authenticated routes
+// such as GET /users/me once the middleware has validated the access token
+func (s *service) GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	return s.queryRepo.GetUserByID(ctx, id)
+}
+
+// SendVerificationEmail issues a new email verification token for the user
+// with the given email and emails it as a confirmation link. Like
+// RequestPasswordReset, it never reveals whether the email is registered: a
+// lookup miss is logged and swallowed. Callers may invoke this repeatedly
+// (e.g. a "resend verification email" endpoint); each call invalidates
+// nothing from a prior call, so an older link stays valid until it expires
+// or is consumed.
+func (s *service) SendVerificationEmail(ctx context.Context, email string) error {
+	log := logger.FromContext(ctx)
+
+	u, err := s.queryRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		log.Debug("Verification email requested for unknown email", "email", email)
+		return nil
+	}
+
+	rawToken, expiresAt, err := s.createVerificationToken(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.sendVerificationEmail(ctx, u, rawToken, expiresAt)
+}
+
+// createVerificationToken generates and persists a new email verification
+// token for userID, returning the raw (unhashed) token so the caller can
+// build a confirmation link from it.
+func (s *service) createVerificationToken(ctx context.Context, userID uuid.UUID) (string, time.Time, error) {
+	log := logger.FromContext(ctx)
+
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, ErrTokenGeneration
+	}
+
+	expiresAt := time.Now().Add(s.cfg.EmailVerificationTTL)
+	if _, err := s.emailVerificationRepo.CreateEmailVerificationToken(ctx, CreateEmailVerificationTokenParams{
+		UserID:      userID,
+		HashedToken: hashRefreshToken(rawToken),
+		ExpiresAt:   expiresAt,
+	}); err != nil {
+		log.Error("Failed to create email verification token", "error", err, "user_id", userID.String())
+		return "", time.Time{}, err
+	}
+
+	return rawToken, expiresAt, nil
+}
+
+// sendVerificationEmail renders and delivers the confirmation email for a
+// previously-created verification token.
+func (s *service) sendVerificationEmail(ctx context.Context, u *user.User, rawToken string, expiresAt time.Time) error {
+	log := logger.FromContext(ctx)
+
+	confirmURL := fmt.Sprintf("%s/api/v1/auth/verify-email?token=%s", s.cfg.AppBaseURL, rawToken)
+	htmlBody, textBody, err := mail.RenderVerifyEmail(u.FirstName, confirmURL, expiresAt)
+	if err != nil {
+		log.Error("Failed to render verification email", "error", err, "user_id", u.ID.String())
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, u.Email, "Verify your email address", htmlBody, textBody); err != nil {
+		log.Error("Failed to send verification email", "error", err, "user_id", u.ID.String())
+		return err
+	}
+
+	return nil
+}
+
+// ConfirmEmail consumes a verification token and marks the owning user's
+// email address as verified.
+func (s *service) ConfirmEmail(ctx context.Context, token string) error {
+	log := logger.FromContext(ctx)
+
+	record, err := s.emailVerificationRepo.GetEmailVerificationTokenByHash(ctx, hashRefreshToken(token))
+	if err != nil {
+		log.Debug("Verification token not found", "error", err)
+		return ErrInvalidVerificationToken
+	}
+
+	if record.ConsumedAt != nil || time.Now().After(record.ExpiresAt) {
+		log.Debug("Verification token already consumed or expired", "user_id", record.UserID.String())
+		return ErrInvalidVerificationToken
+	}
+
+	if err := s.cmdRepo.MarkEmailVerified(ctx, record.UserID); err != nil {
+		return err
+	}
+
+	if err := s.emailVerificationRepo.ConsumeEmailVerificationToken(ctx, record.ID); err != nil {
+		log.Error("Failed to consume verification token", "error", err, "user_id", record.UserID.String())
+		return err
+	}
+
+	return nil
+}
+
+// RequestPasswordReset issues a password reset token and emails it as a
+// reset link. It never reveals whether email is actually registered: a
+// lookup miss is logged and swallowed so this method can't be used to
+// enumerate accounts.
+func (s *service) RequestPasswordReset(ctx context.Context, email string) error {
+	log := logger.FromContext(ctx)
+
+	u, err := s.queryRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		log.Debug("Password reset requested for unknown email", "email", email)
+		return nil
+	}
+
+	rawToken, err := generateOpaqueToken()
+	if err != nil {
+		return ErrTokenGeneration
+	}
+
+	expiresAt := time.Now().Add(s.cfg.PasswordResetTTL)
+	if _, err := s.passwordResetRepo.CreatePasswordResetToken(ctx, CreatePasswordResetTokenParams{
+		UserID:      u.ID,
+		HashedToken: hashRefreshToken(rawToken),
+		ExpiresAt:   expiresAt,
+	}); err != nil {
+		log.Error("Failed to create password reset token", "error", err, "user_id", u.ID.String())
+		return err
+	}
+
+	resetURL := fmt.Sprintf("%s/api/v1/auth/reset-password?token=%s", s.cfg.AppBaseURL, rawToken)
+	htmlBody, textBody, err := mail.RenderPasswordReset(u.FirstName, resetURL, expiresAt)
+	if err != nil {
+		log.Error("Failed to render password reset email", "error", err, "user_id", u.ID.String())
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, u.Email, "Reset your password", htmlBody, textBody); err != nil {
+		log.Error("Failed to send password reset email", "error", err, "user_id", u.ID.String())
+		return err
+	}
+
+	return nil
+}
+
+// ResetPassword consumes a password reset token and replaces the owning
+// user's password hash. The token is looked up and checked first; the hash
+// update and token consumption then run in a single transaction so a
+// failure never leaves a consumed token with an unchanged password, or vice
+// versa.
+func (s *service) ResetPassword(ctx context.Context, token, newPassword string) error {
+	log := logger.FromContext(ctx)
+
+	record, err := s.passwordResetRepo.GetPasswordResetTokenByHash(ctx, hashRefreshToken(token))
+	if err != nil {
+		log.Debug("Password reset token not found", "error", err)
+		return ErrInvalidResetToken
+	}
+
+	if record.ConsumedAt != nil || time.Now().After(record.ExpiresAt) {
+		log.Debug("Password reset token already consumed or expired", "user_id", record.UserID.String())
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := s.hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cmdRepo.UpdatePasswordHash(ctx, record.UserID, hashedPassword); err != nil {
+			return err
+		}
+		if err := s.passwordResetRepo.ConsumePasswordResetToken(ctx, record.ID); err != nil {
+			return err
+		}
+		// A password reset is typically triggered by a suspected account
+		// compromise, so any existing sessions must not survive it.
+		return s.refreshRepo.RevokeAllForUser(ctx, record.UserID)
+	})
+}
+
+// issueTokenPair generates a new access/refresh token pair for a user and
+// persists the refresh token
+func (s *service) issueTokenPair(ctx context.Context, userID uuid.UUID) (*TokenPair, error) {
+	pair, _, err := s.issueTokenPairWithRecord(ctx, userID)
+	return pair, err
+}
+
+// issueTokenPairWithRecord behaves like issueTokenPair but also returns the
+// ID of the persisted refresh token record, needed by callers that must
+// link the old record to its replacement during rotation
+func (s *service) issueTokenPairWithRecord(ctx context.Context, userID uuid.UUID) (*TokenPair, uuid.UUID, error) {
+	rawRefreshToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, uuid.Nil, ErrTokenGeneration
+	}
+
+	refreshExpiresAt := time.Now().Add(refreshTokenTTL)
+	record, err := s.refreshRepo.CreateRefreshToken(ctx, CreateRefreshTokenParams{
+		UserID:      userID,
+		HashedToken: hashRefreshToken(rawRefreshToken),
+		ExpiresAt:   refreshExpiresAt,
+	})
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	// The access token's jti mirrors its refresh token record so that
+	// revoking/rotating the refresh token also invalidates the access token.
+	accessToken, err := s.generateJWTToken(userID, record.ID)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  time.Now().Add(s.cfg.AccessTokenTTL),
+		RefreshToken:          rawRefreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+	}, record.ID, nil
+}
+
+// generateOpaqueToken creates a cryptographically random, URL-safe refresh token
+func generateOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
 		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
 
-	return token, nil
+// hashRefreshToken hashes an opaque refresh token for storage/lookup so the
+// raw token is never persisted
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
-// generateJWTToken generates a JWT token for the given user ID
-func generateJWTToken(userID uuid.UUID) (string, error) {
-	expirationTime := time.Now().Add(jwtExpiry)
+// generateJWTToken generates a JWT token for the given user ID, signed with
+// the current primary signing key. jti ties the access token to its refresh
+// token record so the two can be revoked together. The key's KID is carried
+// in the token header so ValidateToken can look up the matching key even
+// after the primary key rotates.
+func (s *service) generateJWTToken(userID uuid.UUID, jti uuid.UUID) (string, error) {
+	expirationTime := time.Now().Add(s.cfg.AccessTokenTTL)
 
 	claims := &Claims{
 		UserID: userID,
@@ -156,13 +663,17 @@ func generateJWTToken(userID uuid.UUID) (string, error) {
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
-			Issuer:    "splitwise-clone",
+			Issuer:    s.cfg.Issuer,
 			Subject:   userID.String(),
+			ID:        jti.String(),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtSecret)
+	key := s.cfg.primaryKey()
+	token.Header["kid"] = key.KID
+
+	tokenString, err := token.SignedString([]byte(key.Secret))
 	if err != nil {
 		return "", ErrTokenGeneration
 	}
@@ -170,24 +681,12 @@ func generateJWTToken(userID uuid.UUID) (string, error) {
 	return tokenString, nil
 }
 
-// getJWTSecret retrieves JWT secret from environment or uses default for development
-func getJWTSecret() string {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		// Default secret for development only
-		// In production, JWT_SECRET environment variable MUST be set
-		return "dev-secret-key-change-in-production"
-	}
-	return secret
-}
-
-// hashPassword hashes a plain text password using bcrypt with SHA-256
-func hashPassword(password string) (string, error) {
-	// Using bcrypt with cost 12 (recommended for production)
-	// Note: bcrypt internally uses a secure algorithm
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), 12)
+// hashPassword hashes a plain text password using bcrypt at the configured
+// cost factor.
+func (s *service) hashPassword(password string) (string, error) {
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), s.cfg.BcryptCost)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("hash password: %w", err)
 	}
 	return string(hashedBytes), nil
 }
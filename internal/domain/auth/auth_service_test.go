@@ -9,17 +9,19 @@ import (
 
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
-type MockRepository struct {
+type MockCommandRepository struct {
 	mock.Mock
 }
 
-func (m *MockRepository) CreateUser(ctx context.Context, params user.CreateUserParams) (*user.User, error) {
+func (m *MockCommandRepository) CreateUser(ctx context.Context, params user.CreateUserParams) (*user.User, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -27,7 +29,26 @@ func (m *MockRepository) CreateUser(ctx context.Context, params user.CreateUserP
 	return args.Get(0).(*user.User), args.Error(1)
 }
 
-func (m *MockRepository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+func (m *MockCommandRepository) SoftDeleteUser(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCommandRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	args := m.Called(ctx, id, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockCommandRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockQueryRepository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
 	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -35,11 +56,141 @@ func (m *MockRepository) GetUserByEmail(ctx context.Context, email string) (*use
 	return args.Get(0).(*user.User), args.Error(1)
 }
 
-func (m *MockRepository) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
+func (m *MockQueryRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockQueryRepository) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
 	args := m.Called(ctx, email)
 	return args.Bool(0), args.Error(1)
 }
 
+// passthroughTransactor runs fn directly against the caller's context,
+// standing in for a real TxManager in tests that don't exercise an actual
+// database transaction.
+type passthroughTransactor struct{}
+
+func (passthroughTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) CreateRefreshToken(ctx context.Context, params CreateRefreshTokenParams) (*RefreshToken, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, hashedToken string) (*RefreshToken, error) {
+	args := m.Called(ctx, hashedToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) GetRefreshTokenByID(ctx context.Context, id uuid.UUID) (*RefreshToken, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeRefreshToken(ctx context.Context, id uuid.UUID, replacedBy *uuid.UUID) error {
+	args := m.Called(ctx, id, replacedBy)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type MockFederatedIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockFederatedIdentityRepository) GetUserByFederatedIdentity(ctx context.Context, provider, subject string) (*user.User, error) {
+	args := m.Called(ctx, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockFederatedIdentityRepository) LinkFederatedIdentity(ctx context.Context, userID uuid.UUID, provider, subject string) error {
+	args := m.Called(ctx, userID, provider, subject)
+	return args.Error(0)
+}
+
+type MockEmailVerificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailVerificationRepository) CreateEmailVerificationToken(ctx context.Context, params CreateEmailVerificationTokenParams) (*EmailVerificationToken, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*EmailVerificationToken), args.Error(1)
+}
+
+func (m *MockEmailVerificationRepository) GetEmailVerificationTokenByHash(ctx context.Context, hashedToken string) (*EmailVerificationToken, error) {
+	args := m.Called(ctx, hashedToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*EmailVerificationToken), args.Error(1)
+}
+
+func (m *MockEmailVerificationRepository) ConsumeEmailVerificationToken(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockPasswordResetRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetRepository) CreatePasswordResetToken(ctx context.Context, params CreatePasswordResetTokenParams) (*PasswordResetToken, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PasswordResetToken), args.Error(1)
+}
+
+func (m *MockPasswordResetRepository) GetPasswordResetTokenByHash(ctx context.Context, hashedToken string) (*PasswordResetToken, error) {
+	args := m.Called(ctx, hashedToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PasswordResetToken), args.Error(1)
+}
+
+func (m *MockPasswordResetRepository) ConsumePasswordResetToken(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// stubMailer is a no-op Mailer used by tests that don't care about the
+// content of outgoing emails, only that SignUp succeeds despite sending one.
+type stubMailer struct{}
+
+func (stubMailer) Send(ctx context.Context, to, subject, htmlBody, textBody string) error {
+	return nil
+}
+
 func createTestSignUpParams() SignUpParams {
 	return SignUpParams{
 		FirstName: "John",
@@ -65,15 +216,19 @@ func createTestUser() *user.User {
 
 func TestSignUp_Success(t *testing.T) {
 
-	mockRepo := new(MockRepository)
-	service := NewService(mockRepo)
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	mockEmailVerificationRepo := new(MockEmailVerificationRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, new(MockRefreshTokenRepository), new(MockFederatedIdentityRepository), mockEmailVerificationRepo, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), stubMailer{})
 	ctx := context.Background()
 	params := createTestSignUpParams()
 	expectedUser := createTestUser()
 
 	// define what mocks should return
-	mockRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, nil)
-	mockRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).Return(expectedUser, nil)
+	mockQueryRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, nil)
+	mockCmdRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).Return(expectedUser, nil)
+	mockEmailVerificationRepo.On("CreateEmailVerificationToken", ctx, mock.AnythingOfType("CreateEmailVerificationTokenParams")).
+		Return(&EmailVerificationToken{ID: uuid.New(), UserID: expectedUser.ID}, nil)
 
 	result, err := service.SignUp(ctx, params)
 
@@ -85,12 +240,15 @@ func TestSignUp_Success(t *testing.T) {
 	assert.Equal(t, expectedUser.FirstName, result.FirstName, "user first names should match")
 	assert.Equal(t, expectedUser.LastName, result.LastName, "user last names should match")
 
-	mockRepo.AssertExpectations(t)
+	mockCmdRepo.AssertExpectations(t)
+	mockQueryRepo.AssertExpectations(t)
 }
 
 func TestSignUp_OptionalFields(t *testing.T) {
-	mockRepo := new(MockRepository)
-	service := NewService(mockRepo)
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	mockEmailVerificationRepo := new(MockEmailVerificationRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, new(MockRefreshTokenRepository), new(MockFederatedIdentityRepository), mockEmailVerificationRepo, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), stubMailer{})
 	ctx := context.Background()
 
 	phone := "+1234567890"
@@ -108,8 +266,10 @@ func TestSignUp_OptionalFields(t *testing.T) {
 	expectedUser.DateOfBirth = &dob
 	expectedUser.PhoneNumber = &phone
 	// define what mocks should return
-	mockRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, nil)
-	mockRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).Return(expectedUser, nil)
+	mockQueryRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, nil)
+	mockCmdRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).Return(expectedUser, nil)
+	mockEmailVerificationRepo.On("CreateEmailVerificationToken", ctx, mock.AnythingOfType("CreateEmailVerificationTokenParams")).
+		Return(&EmailVerificationToken{ID: uuid.New(), UserID: expectedUser.ID}, nil)
 
 	result, err := service.SignUp(ctx, params)
 
@@ -123,17 +283,19 @@ func TestSignUp_OptionalFields(t *testing.T) {
 	assert.Equal(t, expectedUser.DateOfBirth, result.DateOfBirth, "user date of births should match")
 	assert.Equal(t, expectedUser.PhoneNumber, result.PhoneNumber, "user phone numbers should match")
 
-	mockRepo.AssertExpectations(t)
+	mockCmdRepo.AssertExpectations(t)
+	mockQueryRepo.AssertExpectations(t)
 }
 
 func TestSignUp_UserAlreadyExists(t *testing.T) {
-	mockRepo := new(MockRepository)
-	service := NewService(mockRepo)
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, new(MockRefreshTokenRepository), new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
 	ctx := context.Background()
 	params := createTestSignUpParams()
 
 	// define what mocks should return
-	mockRepo.On("UserExistsByEmail", ctx, params.Email).Return(true, nil)
+	mockQueryRepo.On("UserExistsByEmail", ctx, params.Email).Return(true, nil)
 
 	result, err := service.SignUp(ctx, params)
 
@@ -142,19 +304,20 @@ func TestSignUp_UserAlreadyExists(t *testing.T) {
 	assert.Nil(t, result, "Result should be nil")
 	assert.Equal(t, ErrUserAlreadyExists, err, "Error should be ErrUserAlreadyExists")
 
-	mockRepo.AssertNotCalled(t, "CreateUser")
-	mockRepo.AssertExpectations(t)
+	mockCmdRepo.AssertNotCalled(t, "CreateUser")
+	mockQueryRepo.AssertExpectations(t)
 }
 
 func TestSignUp_UserExistsCheckError(t *testing.T) {
-	mockRepo := new(MockRepository)
-	service := NewService(mockRepo)
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, new(MockRefreshTokenRepository), new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
 	ctx := context.Background()
 	params := createTestSignUpParams()
 
 	// define what mocks should return
 	dbError := errors.New("database error")
-	mockRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, dbError)
+	mockQueryRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, dbError)
 
 	result, err := service.SignUp(ctx, params)
 
@@ -163,20 +326,21 @@ func TestSignUp_UserExistsCheckError(t *testing.T) {
 	assert.Nil(t, result, "Result should be nil")
 	assert.Equal(t, dbError, err, "Error should match the database error")
 
-	mockRepo.AssertNotCalled(t, "CreateUser")
-	mockRepo.AssertExpectations(t)
+	mockCmdRepo.AssertNotCalled(t, "CreateUser")
+	mockQueryRepo.AssertExpectations(t)
 }
 
 func TestSignUp_CreateUserError(t *testing.T) {
-	mockRepo := new(MockRepository)
-	service := NewService(mockRepo)
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, new(MockRefreshTokenRepository), new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
 	ctx := context.Background()
 	params := createTestSignUpParams()
 
 	dbError := errors.New("Database insert error")
 
-	mockRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, nil)
-	mockRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).Return(nil, dbError)
+	mockQueryRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, nil)
+	mockCmdRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).Return(nil, dbError)
 
 	result, err := service.SignUp(ctx, params)
 
@@ -184,25 +348,30 @@ func TestSignUp_CreateUserError(t *testing.T) {
 	assert.Nil(t, result, "Result should be nil")
 	assert.Equal(t, err, dbError, "Error should match the database error")
 
-	mockRepo.AssertExpectations(t)
+	mockCmdRepo.AssertExpectations(t)
+	mockQueryRepo.AssertExpectations(t)
 
 }
 
 func TestSignUp_PasswordIsHashed(t *testing.T) {
-	mockRepo := new(MockRepository)
-	service := NewService(mockRepo)
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	mockEmailVerificationRepo := new(MockEmailVerificationRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, new(MockRefreshTokenRepository), new(MockFederatedIdentityRepository), mockEmailVerificationRepo, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), stubMailer{})
 	ctx := context.Background()
 	params := createTestSignUpParams()
 	expectedUser := createTestUser()
 
 	var capturedParams user.CreateUserParams
 
-	mockRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, nil)
-	mockRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).
+	mockQueryRepo.On("UserExistsByEmail", ctx, params.Email).Return(false, nil)
+	mockCmdRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).
 		Run(func(args mock.Arguments) {
 			capturedParams = args.Get(1).(user.CreateUserParams)
 		}).
 		Return(expectedUser, nil)
+	mockEmailVerificationRepo.On("CreateEmailVerificationToken", ctx, mock.AnythingOfType("CreateEmailVerificationTokenParams")).
+		Return(&EmailVerificationToken{ID: uuid.New(), UserID: expectedUser.ID}, nil)
 
 	result, err := service.SignUp(ctx, params)
 
@@ -218,7 +387,8 @@ func TestSignUp_PasswordIsHashed(t *testing.T) {
 	)
 	assert.NoError(t, err, "Password hash should match original password")
 
-	mockRepo.AssertExpectations(t)
+	mockCmdRepo.AssertExpectations(t)
+	mockQueryRepo.AssertExpectations(t)
 
 }
 
@@ -273,20 +443,25 @@ func TestSignUp_MultipleScenarios(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := new(MockRepository)
-			service := NewService(mockRepo)
+			mockCmdRepo := new(MockCommandRepository)
+			mockQueryRepo := new(MockQueryRepository)
+			mockEmailVerificationRepo := new(MockEmailVerificationRepository)
+			service := NewService(mockCmdRepo, mockQueryRepo, new(MockRefreshTokenRepository), new(MockFederatedIdentityRepository), mockEmailVerificationRepo, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), stubMailer{})
 			ctx := context.Background()
 
-			mockRepo.On("UserExistsByEmail", ctx, tt.params.Email).
+			mockQueryRepo.On("UserExistsByEmail", ctx, tt.params.Email).
 				Return(tt.userExists, tt.existsError)
 
 			if !tt.userExists && tt.existsError == nil {
 				if tt.createError != nil {
-					mockRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).
+					mockCmdRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).
 						Return(nil, tt.createError)
 				} else {
-					mockRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).
-						Return(createTestUser(), nil)
+					createdUser := createTestUser()
+					mockCmdRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).
+						Return(createdUser, nil)
+					mockEmailVerificationRepo.On("CreateEmailVerificationToken", ctx, mock.AnythingOfType("CreateEmailVerificationTokenParams")).
+						Return(&EmailVerificationToken{ID: uuid.New(), UserID: createdUser.ID}, nil)
 				}
 			}
 
@@ -303,7 +478,687 @@ func TestSignUp_MultipleScenarios(t *testing.T) {
 				}
 			}
 
-			mockRepo.AssertExpectations(t)
+			mockCmdRepo.AssertExpectations(t)
+			mockQueryRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestLogin_Success(t *testing.T) {
+	mockQueryRepo := new(MockQueryRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	service := NewService(new(MockCommandRepository), mockQueryRepo, mockRefreshRepo, new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte("securePassword123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	hashedPassword := string(hashedBytes)
+
+	expectedUser := createTestUser()
+	expectedUser.PasswordHash = hashedPassword
+
+	mockQueryRepo.On("GetUserByEmail", ctx, expectedUser.Email).Return(expectedUser, nil)
+	mockRefreshRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("CreateRefreshTokenParams")).
+		Return(&RefreshToken{ID: uuid.New(), UserID: expectedUser.ID}, nil)
+
+	result, err := service.Login(ctx, expectedUser.Email, "securePassword123")
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.NotEmpty(t, result.AccessToken, "access token should be issued")
+	assert.NotEmpty(t, result.RefreshToken, "refresh token should be issued")
+	assert.Equal(t, expectedUser.ID, result.User.ID)
+
+	mockQueryRepo.AssertExpectations(t)
+	mockRefreshRepo.AssertExpectations(t)
+}
+
+func TestLogin_InvalidPassword(t *testing.T) {
+	mockQueryRepo := new(MockQueryRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	service := NewService(new(MockCommandRepository), mockQueryRepo, mockRefreshRepo, new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte("securePassword123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	hashedPassword := string(hashedBytes)
+
+	expectedUser := createTestUser()
+	expectedUser.PasswordHash = hashedPassword
+
+	mockQueryRepo.On("GetUserByEmail", ctx, expectedUser.Email).Return(expectedUser, nil)
+
+	result, err := service.Login(ctx, expectedUser.Email, "wrongPassword")
+
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+	assert.Nil(t, result)
+
+	mockQueryRepo.AssertExpectations(t)
+	mockRefreshRepo.AssertNotCalled(t, "CreateRefreshToken")
+}
+
+func TestLogin_UserNotFound(t *testing.T) {
+	mockQueryRepo := new(MockQueryRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	service := NewService(new(MockCommandRepository), mockQueryRepo, mockRefreshRepo, new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	mockQueryRepo.On("GetUserByEmail", ctx, "missing@example.com").Return(nil, pgx.ErrNoRows)
+
+	result, err := service.Login(ctx, "missing@example.com", "whatever")
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.Nil(t, result)
+
+	mockQueryRepo.AssertExpectations(t)
+}
+
+// TestLogin_LocksOutAfterRepeatedFailures verifies that repeated failed
+// logins for the same email trip the LoginAttemptRecorder's lockout, and
+// that the service surfaces it as ErrAccountLocked instead of continuing to
+// hit the repository.
+func TestLogin_LocksOutAfterRepeatedFailures(t *testing.T) {
+	mockQueryRepo := new(MockQueryRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	attempts := NewInMemoryLoginAttemptRecorder(3, 15*time.Minute)
+	service := NewService(new(MockCommandRepository), mockQueryRepo, mockRefreshRepo, new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), attempts, nil)
+	ctx := context.Background()
+
+	mockQueryRepo.On("GetUserByEmail", ctx, "victim@example.com").Return(nil, pgx.ErrNoRows)
+
+	for i := 0; i < 3; i++ {
+		_, err := service.Login(ctx, "victim@example.com", "wrong-password")
+		assert.ErrorIs(t, err, ErrUserNotFound, "attempt %d should still reach the repository", i+1)
+	}
+
+	result, err := service.Login(ctx, "victim@example.com", "wrong-password")
+
+	var locked *ErrAccountLocked
+	require.ErrorAs(t, err, &locked)
+	assert.Greater(t, locked.RetryAfter, time.Duration(0))
+	assert.Nil(t, result)
+
+	// The repository must not be consulted once locked out.
+	mockQueryRepo.AssertNumberOfCalls(t, "GetUserByEmail", 3)
+}
+
+// TestLogin_SuccessResetsAttemptCounter verifies that a successful login
+// clears any previously recorded failures, so a later mistyped password
+// starts counting from zero rather than compounding toward lockout.
+func TestLogin_SuccessResetsAttemptCounter(t *testing.T) {
+	mockQueryRepo := new(MockQueryRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	attempts := NewInMemoryLoginAttemptRecorder(2, 15*time.Minute)
+	service := NewService(new(MockCommandRepository), mockQueryRepo, mockRefreshRepo, new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), attempts, nil)
+	ctx := context.Background()
+
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	validUser := createTestUser()
+	validUser.Email = "reset@example.com"
+	validUser.PasswordHash = string(hashedBytes)
+
+	mockQueryRepo.On("GetUserByEmail", ctx, validUser.Email).Return(validUser, nil)
+	mockRefreshRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("CreateRefreshTokenParams")).
+		Return(&RefreshToken{ID: uuid.New(), UserID: validUser.ID}, nil)
+
+	_, err = service.Login(ctx, validUser.Email, "wrong-password")
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+
+	result, err := service.Login(ctx, validUser.Email, "correct-password")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	// Had the earlier failure not been cleared, this third attempt would
+	// record the second failure and trip the two-failure lockout.
+	_, err = service.Login(ctx, validUser.Email, "wrong-password")
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+
+	// With the counter reset, the third attempt was only the first failure,
+	// so this fourth attempt still reaches the repository instead of being
+	// rejected as locked out.
+	_, err = service.Login(ctx, validUser.Email, "wrong-password")
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+}
+
+func TestRefreshToken_RotatesToken(t *testing.T) {
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	service := NewService(new(MockCommandRepository), new(MockQueryRepository), mockRefreshRepo, new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	existing := &RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mockRefreshRepo.On("GetRefreshTokenByHash", ctx, mock.AnythingOfType("string")).Return(existing, nil)
+	mockRefreshRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("CreateRefreshTokenParams")).
+		Return(&RefreshToken{ID: uuid.New(), UserID: userID}, nil)
+	mockRefreshRepo.On("RevokeRefreshToken", ctx, existing.ID, mock.AnythingOfType("*uuid.UUID")).Return(nil)
+
+	pair, err := service.RefreshToken(ctx, "some-refresh-token")
+
+	require.NoError(t, err)
+	require.NotNil(t, pair)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.NotEmpty(t, pair.RefreshToken)
+
+	mockRefreshRepo.AssertExpectations(t)
+}
+
+func TestRefreshToken_ReuseDetectedRevokesFamily(t *testing.T) {
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	service := NewService(new(MockCommandRepository), new(MockQueryRepository), mockRefreshRepo, new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	revokedAt := time.Now().Add(-time.Minute)
+	existing := &RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour),
+		RevokedAt: &revokedAt,
+	}
+
+	mockRefreshRepo.On("GetRefreshTokenByHash", ctx, mock.AnythingOfType("string")).Return(existing, nil)
+	mockRefreshRepo.On("RevokeAllForUser", ctx, userID).Return(nil)
+
+	pair, err := service.RefreshToken(ctx, "stolen-refresh-token")
+
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.Nil(t, pair)
+
+	mockRefreshRepo.AssertExpectations(t)
+	mockRefreshRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+func TestLogout_RevokesToken(t *testing.T) {
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	service := NewService(new(MockCommandRepository), new(MockQueryRepository), mockRefreshRepo, new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	existing := &RefreshToken{ID: uuid.New(), UserID: uuid.New()}
+
+	mockRefreshRepo.On("GetRefreshTokenByHash", ctx, mock.AnythingOfType("string")).Return(existing, nil)
+	mockRefreshRepo.On("RevokeRefreshToken", ctx, existing.ID, (*uuid.UUID)(nil)).Return(nil)
+
+	err := service.Logout(ctx, "some-refresh-token")
+
+	assert.NoError(t, err)
+	mockRefreshRepo.AssertExpectations(t)
+}
+
+func TestLoginWithFederatedIdentity_ExistingUser(t *testing.T) {
+	mockCmdRepo := new(MockCommandRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	mockFederatedRepo := new(MockFederatedIdentityRepository)
+	service := NewService(mockCmdRepo, new(MockQueryRepository), mockRefreshRepo, mockFederatedRepo, nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	identity := FederatedIdentity{Provider: "google", Subject: "google-subject-123", Email: "john.doe@example.com"}
+	expectedUser := createTestUser()
+
+	mockFederatedRepo.On("GetUserByFederatedIdentity", ctx, identity.Provider, identity.Subject).Return(expectedUser, nil)
+	mockRefreshRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("CreateRefreshTokenParams")).
+		Return(&RefreshToken{ID: uuid.New(), UserID: expectedUser.ID}, nil)
+
+	result, err := service.LoginWithFederatedIdentity(ctx, identity)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, expectedUser.ID, result.User.ID)
+
+	mockFederatedRepo.AssertNotCalled(t, "LinkFederatedIdentity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockCmdRepo.AssertNotCalled(t, "CreateUser")
+}
+
+func TestLoginWithFederatedIdentity_AutoProvisionsWithoutPassword(t *testing.T) {
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	mockFederatedRepo := new(MockFederatedIdentityRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, mockRefreshRepo, mockFederatedRepo, nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	identity := FederatedIdentity{
+		Provider:  "github",
+		Subject:   "github-subject-456",
+		Email:     "new.user@example.com",
+		FirstName: "New",
+		LastName:  "User",
+	}
+
+	provisionedUser := &user.User{
+		ID:        uuid.New(),
+		FirstName: identity.FirstName,
+		LastName:  identity.LastName,
+		Email:     identity.Email,
+	}
+
+	var capturedParams user.CreateUserParams
+
+	mockFederatedRepo.On("GetUserByFederatedIdentity", ctx, identity.Provider, identity.Subject).Return(nil, pgx.ErrNoRows)
+	mockQueryRepo.On("GetUserByEmail", ctx, identity.Email).Return(nil, pgx.ErrNoRows)
+	mockCmdRepo.On("CreateUser", ctx, mock.AnythingOfType("user.CreateUserParams")).
+		Run(func(args mock.Arguments) {
+			capturedParams = args.Get(1).(user.CreateUserParams)
+		}).
+		Return(provisionedUser, nil)
+	mockFederatedRepo.On("LinkFederatedIdentity", ctx, provisionedUser.ID, identity.Provider, identity.Subject).Return(nil)
+	mockRefreshRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("CreateRefreshTokenParams")).
+		Return(&RefreshToken{ID: uuid.New(), UserID: provisionedUser.ID}, nil)
+
+	result, err := service.LoginWithFederatedIdentity(ctx, identity)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, provisionedUser.ID, result.User.ID)
+	assert.Empty(t, capturedParams.PasswordHash, "federated accounts should have no password set")
+
+	mockCmdRepo.AssertExpectations(t)
+	mockQueryRepo.AssertExpectations(t)
+	mockFederatedRepo.AssertExpectations(t)
+}
+
+// TestLoginWithFederatedIdentity_SurfacesLookupError verifies that a
+// transient repository error from GetUserByFederatedIdentity is returned to
+// the caller as-is, rather than being treated as "identity not seen before"
+// and triggering auto-provisioning.
+func TestLoginWithFederatedIdentity_SurfacesLookupError(t *testing.T) {
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	mockFederatedRepo := new(MockFederatedIdentityRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, mockRefreshRepo, mockFederatedRepo, nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	identity := FederatedIdentity{
+		Provider: "github",
+		Subject:  "github-subject-456",
+		Email:    "new.user@example.com",
+	}
+
+	dbErr := errors.New("connection reset by peer")
+	mockFederatedRepo.On("GetUserByFederatedIdentity", ctx, identity.Provider, identity.Subject).Return(nil, dbErr)
+
+	result, err := service.LoginWithFederatedIdentity(ctx, identity)
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, dbErr)
+
+	mockCmdRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	mockQueryRepo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+	mockFederatedRepo.AssertExpectations(t)
+}
+
+// TestLoginWithFederatedIdentity_LinksExistingAccountByEmail verifies that
+// when a federated identity isn't linked yet but an account with the same
+// email already exists (e.g. from a password signup, or a different
+// provider), the identity is linked to that account instead of attempting
+// to create a second user row.
+func TestLoginWithFederatedIdentity_LinksExistingAccountByEmail(t *testing.T) {
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	mockFederatedRepo := new(MockFederatedIdentityRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, mockRefreshRepo, mockFederatedRepo, nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	identity := FederatedIdentity{
+		Provider:  "google",
+		Subject:   "google-subject-789",
+		Email:     "existing.user@example.com",
+		FirstName: "Existing",
+		LastName:  "User",
+	}
+
+	existingUser := &user.User{
+		ID:           uuid.New(),
+		FirstName:    "Existing",
+		LastName:     "User",
+		Email:        identity.Email,
+		PasswordHash: "$2a$12$alreadyhashed",
+	}
+
+	mockFederatedRepo.On("GetUserByFederatedIdentity", ctx, identity.Provider, identity.Subject).Return(nil, pgx.ErrNoRows)
+	mockQueryRepo.On("GetUserByEmail", ctx, identity.Email).Return(existingUser, nil)
+	mockFederatedRepo.On("LinkFederatedIdentity", ctx, existingUser.ID, identity.Provider, identity.Subject).Return(nil)
+	mockRefreshRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("CreateRefreshTokenParams")).
+		Return(&RefreshToken{ID: uuid.New(), UserID: existingUser.ID}, nil)
+
+	result, err := service.LoginWithFederatedIdentity(ctx, identity)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, existingUser.ID, result.User.ID)
+
+	mockCmdRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	mockQueryRepo.AssertExpectations(t)
+	mockFederatedRepo.AssertExpectations(t)
+}
+
+// TestLoginWithFederatedIdentity_SurfacesProvisioningLookupError verifies
+// that a transient error from GetUserByEmail during auto-provisioning is
+// returned to the caller rather than being treated as "no account with this
+// email" and falling through to CreateUser.
+func TestLoginWithFederatedIdentity_SurfacesProvisioningLookupError(t *testing.T) {
+	mockCmdRepo := new(MockCommandRepository)
+	mockQueryRepo := new(MockQueryRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	mockFederatedRepo := new(MockFederatedIdentityRepository)
+	service := NewService(mockCmdRepo, mockQueryRepo, mockRefreshRepo, mockFederatedRepo, nil, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+	ctx := context.Background()
+
+	identity := FederatedIdentity{
+		Provider: "google",
+		Subject:  "google-subject-789",
+		Email:    "existing.user@example.com",
+	}
+
+	dbErr := errors.New("connection reset by peer")
+	mockFederatedRepo.On("GetUserByFederatedIdentity", ctx, identity.Provider, identity.Subject).Return(nil, pgx.ErrNoRows)
+	mockQueryRepo.On("GetUserByEmail", ctx, identity.Email).Return(nil, dbErr)
+
+	result, err := service.LoginWithFederatedIdentity(ctx, identity)
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, dbErr)
+
+	mockCmdRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	mockFederatedRepo.AssertNotCalled(t, "LinkFederatedIdentity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockQueryRepo.AssertExpectations(t)
+}
+
+func TestConfirmEmail_MultipleScenarios(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		lookupToken *EmailVerificationToken
+		lookupErr   error
+		wantErr     error
+	}{
+		{
+			name:        "valid token",
+			lookupToken: &EmailVerificationToken{ID: uuid.New(), UserID: userID, ExpiresAt: now.Add(time.Hour)},
+			wantErr:     nil,
+		},
+		{
+			name:      "token not found",
+			lookupErr: errors.New("not found"),
+			wantErr:   ErrInvalidVerificationToken,
+		},
+		{
+			name:        "token expired",
+			lookupToken: &EmailVerificationToken{ID: uuid.New(), UserID: userID, ExpiresAt: now.Add(-time.Hour)},
+			wantErr:     ErrInvalidVerificationToken,
+		},
+		{
+			name:        "token already consumed",
+			lookupToken: &EmailVerificationToken{ID: uuid.New(), UserID: userID, ExpiresAt: now.Add(time.Hour), ConsumedAt: &now},
+			wantErr:     ErrInvalidVerificationToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCmdRepo := new(MockCommandRepository)
+			mockEmailVerificationRepo := new(MockEmailVerificationRepository)
+			service := NewService(mockCmdRepo, new(MockQueryRepository), new(MockRefreshTokenRepository), new(MockFederatedIdentityRepository), mockEmailVerificationRepo, nil, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), stubMailer{})
+			ctx := context.Background()
+
+			mockEmailVerificationRepo.On("GetEmailVerificationTokenByHash", ctx, mock.AnythingOfType("string")).
+				Return(tt.lookupToken, tt.lookupErr)
+
+			if tt.wantErr == nil {
+				mockCmdRepo.On("MarkEmailVerified", ctx, userID).Return(nil)
+				mockEmailVerificationRepo.On("ConsumeEmailVerificationToken", ctx, tt.lookupToken.ID).Return(nil)
+			}
+
+			err := service.ConfirmEmail(ctx, "some-raw-token")
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				mockCmdRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+			}
+			mockCmdRepo.AssertExpectations(t)
+			mockEmailVerificationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResetPassword_MultipleScenarios(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		lookupToken *PasswordResetToken
+		lookupErr   error
+		wantErr     error
+	}{
+		{
+			name:        "valid token",
+			lookupToken: &PasswordResetToken{ID: uuid.New(), UserID: userID, ExpiresAt: now.Add(time.Hour)},
+			wantErr:     nil,
+		},
+		{
+			name:      "token not found",
+			lookupErr: errors.New("not found"),
+			wantErr:   ErrInvalidResetToken,
+		},
+		{
+			name:        "token expired",
+			lookupToken: &PasswordResetToken{ID: uuid.New(), UserID: userID, ExpiresAt: now.Add(-time.Hour)},
+			wantErr:     ErrInvalidResetToken,
+		},
+		{
+			name:        "token already consumed",
+			lookupToken: &PasswordResetToken{ID: uuid.New(), UserID: userID, ExpiresAt: now.Add(time.Hour), ConsumedAt: &now},
+			wantErr:     ErrInvalidResetToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCmdRepo := new(MockCommandRepository)
+			mockRefreshRepo := new(MockRefreshTokenRepository)
+			mockPasswordResetRepo := new(MockPasswordResetRepository)
+			service := NewService(mockCmdRepo, new(MockQueryRepository), mockRefreshRepo, new(MockFederatedIdentityRepository), nil, mockPasswordResetRepo, passthroughTransactor{}, DefaultConfig(), NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), stubMailer{})
+			ctx := context.Background()
+
+			mockPasswordResetRepo.On("GetPasswordResetTokenByHash", ctx, mock.AnythingOfType("string")).
+				Return(tt.lookupToken, tt.lookupErr)
+
+			if tt.wantErr == nil {
+				mockCmdRepo.On("UpdatePasswordHash", ctx, userID, mock.AnythingOfType("string")).Return(nil)
+				mockPasswordResetRepo.On("ConsumePasswordResetToken", ctx, tt.lookupToken.ID).Return(nil)
+				mockRefreshRepo.On("RevokeAllForUser", ctx, userID).Return(nil)
+			}
+
+			err := service.ResetPassword(ctx, "some-raw-token", "newSecurePassword123")
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				mockCmdRepo.AssertNotCalled(t, "UpdatePasswordHash", mock.Anything, mock.Anything, mock.Anything)
+				mockRefreshRepo.AssertNotCalled(t, "RevokeAllForUser", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+			}
+			mockCmdRepo.AssertExpectations(t)
+			mockPasswordResetRepo.AssertExpectations(t)
+			mockRefreshRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	userID := uuid.New()
+	cfg := DefaultConfig()
+
+	signToken := func(method jwt.SigningMethod, expiresAt time.Time, jti string) string {
+		claims := &Claims{
+			UserID: userID,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+				IssuedAt:  jwt.NewNumericDate(time.Now()),
+				Issuer:    "splitwise-clone",
+				Subject:   userID.String(),
+				ID:        jti,
+			},
+		}
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = cfg.primaryKey().KID
+
+		var key interface{} = []byte(cfg.primaryKey().Secret)
+		if method == jwt.SigningMethodNone {
+			key = jwt.UnsafeAllowNoneSignatureType
+		}
+
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(*MockRefreshTokenRepository, string)
+		token     func(jti string) string
+		wantErr   error
+	}{
+		{
+			name: "valid token",
+			token: func(jti string) string {
+				return signToken(jwt.SigningMethodHS256, time.Now().Add(time.Hour), jti)
+			},
+			setupMock: func(m *MockRefreshTokenRepository, jti string) {
+				id, _ := uuid.Parse(jti)
+				m.On("GetRefreshTokenByID", mock.Anything, id).Return(&RefreshToken{ID: id, UserID: userID}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expired token",
+			token: func(jti string) string {
+				return signToken(jwt.SigningMethodHS256, time.Now().Add(-time.Hour), jti)
+			},
+			setupMock: func(m *MockRefreshTokenRepository, jti string) {},
+			wantErr:   ErrInvalidToken,
+		},
+		{
+			name: "wrong signing method",
+			token: func(jti string) string {
+				return signToken(jwt.SigningMethodNone, time.Now().Add(time.Hour), jti)
+			},
+			setupMock: func(m *MockRefreshTokenRepository, jti string) {},
+			wantErr:   ErrInvalidToken,
+		},
+		{
+			name: "malformed token",
+			token: func(jti string) string {
+				return "not-a-jwt"
+			},
+			setupMock: func(m *MockRefreshTokenRepository, jti string) {},
+			wantErr:   ErrInvalidToken,
+		},
+		{
+			name: "revoked refresh family",
+			token: func(jti string) string {
+				return signToken(jwt.SigningMethodHS256, time.Now().Add(time.Hour), jti)
+			},
+			setupMock: func(m *MockRefreshTokenRepository, jti string) {
+				id, _ := uuid.Parse(jti)
+				revokedAt := time.Now()
+				m.On("GetRefreshTokenByID", mock.Anything, id).Return(&RefreshToken{ID: id, UserID: userID, RevokedAt: &revokedAt}, nil)
+			},
+			wantErr: ErrTokenRevoked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRefreshRepo := new(MockRefreshTokenRepository)
+			service := NewService(new(MockCommandRepository), new(MockQueryRepository), mockRefreshRepo, new(MockFederatedIdentityRepository), nil, nil, passthroughTransactor{}, cfg, NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+
+			jti := uuid.New().String()
+			tt.setupMock(mockRefreshRepo, jti)
+
+			claims, err := service.ValidateToken(context.Background(), tt.token(jti))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, claims)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, claims)
+				assert.Equal(t, userID, claims.UserID)
+			}
+		})
+	}
+}
+
+// TestValidateToken_KeyRotation verifies that a token signed with a previous
+// signing key still validates once that key is kept (not primary) in
+// Config.SigningKeys, and stops validating once the key is retired entirely.
+func TestValidateToken_KeyRotation(t *testing.T) {
+	userID := uuid.New()
+
+	oldKey := SigningKey{KID: "key-1", Secret: "old-secret"}
+	newKey := SigningKey{KID: "key-2", Secret: "new-secret"}
+
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	mockFederatedRepo := new(MockFederatedIdentityRepository)
+
+	oldCfg := Config{
+		BcryptCost:     10,
+		AccessTokenTTL: time.Hour,
+		Issuer:         "splitwise-clone",
+		SigningKeys:    []SigningKey{oldKey},
+	}
+	serviceWithOldKey := NewService(new(MockCommandRepository), new(MockQueryRepository), mockRefreshRepo, mockFederatedRepo, nil, nil, passthroughTransactor{}, oldCfg, NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+
+	refreshRecordID := uuid.New()
+	mockRefreshRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("CreateRefreshTokenParams")).
+		Return(&RefreshToken{ID: refreshRecordID, UserID: userID}, nil)
+	mockRefreshRepo.On("GetRefreshTokenByID", mock.Anything, refreshRecordID).
+		Return(&RefreshToken{ID: refreshRecordID, UserID: userID}, nil)
+
+	tokenPair, err := serviceWithOldKey.(*service).issueTokenPair(context.Background(), userID)
+	require.NoError(t, err)
+
+	// After rotation, the new key is primary but the old key is still
+	// configured so tokens it already signed keep validating.
+	rotatedCfg := Config{
+		BcryptCost:     oldCfg.BcryptCost,
+		AccessTokenTTL: oldCfg.AccessTokenTTL,
+		Issuer:         oldCfg.Issuer,
+		SigningKeys:    []SigningKey{newKey, oldKey},
+	}
+	serviceWithBothKeys := NewService(new(MockCommandRepository), new(MockQueryRepository), mockRefreshRepo, mockFederatedRepo, nil, nil, passthroughTransactor{}, rotatedCfg, NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+
+	claims, err := serviceWithBothKeys.ValidateToken(context.Background(), tokenPair.AccessToken)
+	require.NoError(t, err)
+	require.NotNil(t, claims)
+	assert.Equal(t, userID, claims.UserID)
+
+	// Once the old key is retired entirely, the same token must be rejected.
+	retiredCfg := Config{
+		BcryptCost:     oldCfg.BcryptCost,
+		AccessTokenTTL: oldCfg.AccessTokenTTL,
+		Issuer:         oldCfg.Issuer,
+		SigningKeys:    []SigningKey{newKey},
+	}
+	serviceWithRetiredKey := NewService(new(MockCommandRepository), new(MockQueryRepository), mockRefreshRepo, mockFederatedRepo, nil, nil, passthroughTransactor{}, retiredCfg, NewInMemoryLoginAttemptRecorder(5, 15*time.Minute), nil)
+
+	claims, err = serviceWithRetiredKey.ValidateToken(context.Background(), tokenPair.AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Nil(t, claims)
+}
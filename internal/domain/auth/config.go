@@ -0,0 +1,69 @@
+package auth
+
+import "time"
+
+// SigningKey is a named HMAC key used to sign and verify access tokens.
+// Keeping a list (rather than a single secret) lets operators rotate
+// JWT_SECRET without invalidating sessions already in flight: a new key is
+// added as the primary, while the retired key remains configured just long
+// enough to validate tokens it already signed.
+type SigningKey struct {
+	KID    string
+	Secret string
+}
+
+// Config holds the tunable parameters of the auth service's security policy.
+type Config struct {
+	// BcryptCost is the work factor used when hashing passwords.
+	BcryptCost int
+	// AccessTokenTTL controls how long issued access tokens remain valid.
+	AccessTokenTTL time.Duration
+	// Issuer is embedded as the JWT "iss" claim.
+	Issuer string
+	// SigningKeys lists the keys used to sign and verify access tokens. The
+	// first entry is the primary key used to sign new tokens; the rest are
+	// retained only to validate tokens signed before a rotation.
+	SigningKeys []SigningKey
+	// EmailVerificationTTL controls how long an email verification link
+	// remains valid before it must be re-requested.
+	EmailVerificationTTL time.Duration
+	// PasswordResetTTL controls how long a password reset link remains valid.
+	PasswordResetTTL time.Duration
+	// RequireEmailVerification, when set, makes Login reject credentials for
+	// an account that hasn't confirmed its email address yet.
+	RequireEmailVerification bool
+	// AppBaseURL is prepended to the verification/reset tokens to build the
+	// links sent to users by email.
+	AppBaseURL string
+}
+
+// DefaultConfig returns a usable configuration for local development. It
+// must not be used in production: the signing key is well-known.
+func DefaultConfig() Config {
+	return Config{
+		BcryptCost:     12,
+		AccessTokenTTL: 15 * time.Minute,
+		Issuer:         "splitwise-clone",
+		SigningKeys: []SigningKey{
+			{KID: "dev", Secret: "dev-secret-key-change-in-production"},
+		},
+		EmailVerificationTTL: 24 * time.Hour,
+		PasswordResetTTL:     time.Hour,
+		AppBaseURL:           "http://localhost:8080",
+	}
+}
+
+// primaryKey returns the signing key used to sign new access tokens.
+func (c Config) primaryKey() SigningKey {
+	return c.SigningKeys[0]
+}
+
+// keyByKID returns the signing key matching kid, if one is configured.
+func (c Config) keyByKID(kid string) (SigningKey, bool) {
+	for _, k := range c.SigningKeys {
+		if k.KID == kid {
+			return k, true
+		}
+	}
+	return SigningKey{}, false
+}
@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"context"
+	"splitwise-clone/internal/domain/user"
+)
+
+// contextKey is an unexported type to avoid collisions with context keys
+// defined in other packages
+type contextKey string
+
+const userContextKey contextKey = "auth_user"
+
+// WithUser returns a copy of ctx carrying the authenticated user
+func WithUser(ctx context.Context, u *user.User) context.Context {
+	return context.WithValue(ctx, userContextKey, u)
+}
+
+// UserFromContext retrieves the authenticated user previously stored by
+// WithUser, typically by the JWT auth middleware
+func UserFromContext(ctx context.Context) (*user.User, bool) {
+	u, ok := ctx.Value(userContextKey).(*user.User)
+	return u, ok
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationToken represents a persisted, single-use token proving
+// control of a user's email address.
+type EmailVerificationToken struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	HashedToken string
+	ExpiresAt   time.Time
+	ConsumedAt  *time.Time
+	CreatedAt   time.Time
+}
+
+// CreateEmailVerificationTokenParams contains parameters for persisting a
+// new email verification token
+type CreateEmailVerificationTokenParams struct {
+	UserID      uuid.UUID
+	HashedToken string
+	ExpiresAt   time.Time
+}
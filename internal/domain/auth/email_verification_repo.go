@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"splitwise-clone/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EmailVerificationRepository defines the interface for email verification
+// token persistence
+type EmailVerificationRepository interface {
+	CreateEmailVerificationToken(ctx context.Context, params CreateEmailVerificationTokenParams) (*EmailVerificationToken, error)
+	GetEmailVerificationTokenByHash(ctx context.Context, hashedToken string) (*EmailVerificationToken, error)
+	ConsumeEmailVerificationToken(ctx context.Context, id uuid.UUID) error
+}
+
+// emailVerificationRepository implements the EmailVerificationRepository interface
+type emailVerificationRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewEmailVerificationRepository creates a new email verification repository instance
+func NewEmailVerificationRepository(db *pgxpool.Pool) EmailVerificationRepository {
+	return &emailVerificationRepository{
+		db: db,
+	}
+}
+
+// CreateEmailVerificationToken inserts a new email verification token into the database
+func (r *emailVerificationRepository) CreateEmailVerificationToken(ctx context.Context, params CreateEmailVerificationTokenParams) (*EmailVerificationToken, error) {
+	log := logger.FromContext(ctx)
+
+	query := `
+		INSERT INTO email_verification_tokens (user_id, hashed_token, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, user_id, hashed_token, expires_at, consumed_at, created_at
+	`
+
+	var t EmailVerificationToken
+	err := querierFromContext(ctx, r.db).QueryRow(ctx, query, params.UserID, params.HashedToken, params.ExpiresAt).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.HashedToken,
+		&t.ExpiresAt,
+		&t.ConsumedAt,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		log.Error("Failed to insert email verification token", "error", err, "user_id", params.UserID.String())
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// GetEmailVerificationTokenByHash retrieves an email verification token by its hashed value
+func (r *emailVerificationRepository) GetEmailVerificationTokenByHash(ctx context.Context, hashedToken string) (*EmailVerificationToken, error) {
+	log := logger.FromContext(ctx)
+
+	query := `
+		SELECT id, user_id, hashed_token, expires_at, consumed_at, created_at
+		FROM email_verification_tokens
+		WHERE hashed_token = $1
+	`
+
+	var t EmailVerificationToken
+	err := r.db.QueryRow(ctx, query, hashedToken).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.HashedToken,
+		&t.ExpiresAt,
+		&t.ConsumedAt,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		log.Debug("Email verification token not found or query failed", "error", err)
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// ConsumeEmailVerificationToken marks an email verification token as used
+func (r *emailVerificationRepository) ConsumeEmailVerificationToken(ctx context.Context, id uuid.UUID) error {
+	log := logger.FromContext(ctx)
+
+	query := `UPDATE email_verification_tokens SET consumed_at = now() WHERE id = $1`
+
+	if _, err := querierFromContext(ctx, r.db).Exec(ctx, query, id); err != nil {
+		log.Error("Failed to consume email verification token", "error", err, "token_id", id.String())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package auth
+
+import "context"
+
+// FederatedIdentity represents the profile information returned by an
+// external identity provider after a successful OAuth2/OIDC exchange
+type FederatedIdentity struct {
+	Provider  string
+	Subject   string
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+// FederatedProvider defines the interface implemented by pluggable
+// OAuth2/OIDC identity providers (Google, GitHub, ...)
+type FederatedProvider interface {
+	// Name returns the provider's identifier, e.g. "google" or "github"
+	Name() string
+	// AuthCodeURL builds the URL the user is redirected to in order to
+	// start the authorization-code flow. codeVerifier is a fresh PKCE
+	// verifier for this attempt; its derived S256 challenge is sent in the
+	// URL, and the same verifier must be passed back to Exchange,
+	// mitigating authorization-code interception.
+	AuthCodeURL(state, codeVerifier string) string
+	// Exchange trades an authorization code for the caller's identity.
+	// codeVerifier is the same PKCE verifier passed to AuthCodeURL for this
+	// attempt.
+	Exchange(ctx context.Context, code, codeVerifier string) (FederatedIdentity, error)
+}
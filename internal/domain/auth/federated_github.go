@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+// githubProvider implements FederatedProvider for GitHub sign-in
+type githubProvider struct {
+	config *oauth2.Config
+}
+
+// NewGitHubProvider creates a FederatedProvider backed by GitHub OAuth2
+func NewGitHubProvider(clientID, clientSecret, redirectURL string) FederatedProvider {
+	return &githubProvider{
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       []string{"read:user", "user:email"},
+			Endpoint:     github.Endpoint,
+		},
+	}
+}
+
+func (p *githubProvider) Name() string {
+	return "github"
+}
+
+func (p *githubProvider) AuthCodeURL(state, codeVerifier string) string {
+	return p.config.AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier))
+}
+
+func (p *githubProvider) Exchange(ctx context.Context, code, codeVerifier string) (FederatedIdentity, error) {
+	token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return FederatedIdentity{}, fmt.Errorf("exchanging github authorization code: %w", err)
+	}
+
+	client := p.config.Client(ctx, token)
+
+	userResp, err := client.Get("https://api.github.com/user")
+	if err != nil {
+		return FederatedIdentity{}, fmt.Errorf("fetching github user: %w", err)
+	}
+	defer userResp.Body.Close()
+
+	var ghUser struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(userResp.Body).Decode(&ghUser); err != nil {
+		return FederatedIdentity{}, fmt.Errorf("decoding github user: %w", err)
+	}
+
+	email, err := fetchGitHubPrimaryEmail(client)
+	if err != nil {
+		return FederatedIdentity{}, err
+	}
+
+	firstName, lastName := splitDisplayName(ghUser.Name)
+
+	return FederatedIdentity{
+		Provider:  p.Name(),
+		Subject:   fmt.Sprintf("%d", ghUser.ID),
+		Email:     email,
+		FirstName: firstName,
+		LastName:  lastName,
+	}, nil
+}
+
+// fetchGitHubPrimaryEmail looks up the user's verified primary email, which
+// is not included on the /user endpoint when the account keeps it private
+func fetchGitHubPrimaryEmail(client *http.Client) (string, error) {
+	resp, err := client.Get("https://api.github.com/user/emails")
+	if err != nil {
+		return "", fmt.Errorf("fetching github emails: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&emails); err != nil {
+		return "", fmt.Errorf("decoding github emails: %w", err)
+	}
+
+	for _, e := range emails {
+		if e.Primary && e.Verified {
+			return e.Email, nil
+		}
+	}
+
+	return "", fmt.Errorf("no verified primary email on github account")
+}
+
+// splitDisplayName splits a GitHub display name into first/last name parts
+func splitDisplayName(name string) (string, string) {
+	if name == "" {
+		return "", ""
+	}
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == ' ' {
+			return name[:i], name[i+1:]
+		}
+	}
+	return name, ""
+}
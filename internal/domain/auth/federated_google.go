@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// googleProvider implements FederatedProvider for Google sign-in
+type googleProvider struct {
+	config *oauth2.Config
+}
+
+// NewGoogleProvider creates a FederatedProvider backed by Google OAuth2
+func NewGoogleProvider(clientID, clientSecret, redirectURL string) FederatedProvider {
+	return &googleProvider{
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       []string{"openid", "email", "profile"},
+			Endpoint:     google.Endpoint,
+		},
+	}
+}
+
+func (p *googleProvider) Name() string {
+	return "google"
+}
+
+func (p *googleProvider) AuthCodeURL(state, codeVerifier string) string {
+	return p.config.AuthCodeURL(state, oauth2.AccessTypeOnline, oauth2.S256ChallengeOption(codeVerifier))
+}
+
+func (p *googleProvider) Exchange(ctx context.Context, code, codeVerifier string) (FederatedIdentity, error) {
+	token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return FederatedIdentity{}, fmt.Errorf("exchanging google authorization code: %w", err)
+	}
+
+	resp, err := p.config.Client(ctx, token).Get("https://www.googleapis.com/oauth2/v3/userinfo")
+	if err != nil {
+		return FederatedIdentity{}, fmt.Errorf("fetching google userinfo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var info struct {
+		Sub        string `json:"sub"`
+		Email      string `json:"email"`
+		GivenName  string `json:"given_name"`
+		FamilyName string `json:"family_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return FederatedIdentity{}, fmt.Errorf("decoding google userinfo: %w", err)
+	}
+
+	return FederatedIdentity{
+		Provider:  p.Name(),
+		Subject:   info.Sub,
+		Email:     info.Email,
+		FirstName: info.GivenName,
+		LastName:  info.FamilyName,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"splitwise-clone/internal/domain/user"
+	"splitwise-clone/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FederatedIdentityRepository defines the interface for linking external
+// identity provider accounts to local users
+type FederatedIdentityRepository interface {
+	GetUserByFederatedIdentity(ctx context.Context, provider, subject string) (*user.User, error)
+	LinkFederatedIdentity(ctx context.Context, userID uuid.UUID, provider, subject string) error
+}
+
+// federatedIdentityRepository implements the FederatedIdentityRepository interface
+type federatedIdentityRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewFederatedIdentityRepository creates a new federated identity repository instance
+func NewFederatedIdentityRepository(db *pgxpool.Pool) FederatedIdentityRepository {
+	return &federatedIdentityRepository{
+		db: db,
+	}
+}
+
+// GetUserByFederatedIdentity looks up the local user linked to an external
+// (provider, subject) pair
+func (r *federatedIdentityRepository) GetUserByFederatedIdentity(ctx context.Context, provider, subject string) (*user.User, error) {
+	log := logger.FromContext(ctx)
+
+	query := `
+		SELECT u.id, u.first_name, u.last_name, u.date_of_birth, u.email, u.password_hash, u.phone_number, u.email_verified_at, u.created_at, u.updated_at, u.deleted_at
+		FROM federated_identities fi
+		JOIN users u ON u.id = fi.user_id
+		WHERE fi.provider = $1 AND fi.subject = $2 AND u.deleted_at IS NULL
+	`
+
+	log.Debug("Executing GetUserByFederatedIdentity query", "provider", provider)
+
+	var u user.User
+	err := r.db.QueryRow(ctx, query, provider, subject).Scan(
+		&u.ID,
+		&u.FirstName,
+		&u.LastName,
+		&u.DateOfBirth,
+		&u.Email,
+		&u.PasswordHash,
+		&u.PhoneNumber,
+		&u.EmailVerifiedAt,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+		&u.DeletedAt,
+	)
+	if err != nil {
+		log.Debug("Federated identity not found or query failed", "error", err, "provider", provider)
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+// LinkFederatedIdentity records that (provider, subject) maps to userID. It
+// honors a transaction placed in ctx by TxManager.WithTransaction, so it can
+// be composed atomically with a command repository write such as CreateUser.
+func (r *federatedIdentityRepository) LinkFederatedIdentity(ctx context.Context, userID uuid.UUID, provider, subject string) error {
+	log := logger.FromContext(ctx)
+
+	query := `
+		INSERT INTO federated_identities (user_id, provider, subject)
+		VALUES ($1, $2, $3)
+	`
+
+	if _, err := querierFromContext(ctx, r.db).Exec(ctx, query, userID, provider, subject); err != nil {
+		log.Error("Failed to link federated identity", "error", err, "provider", provider, "user_id", userID.String())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ErrAccountLocked is returned by Service.Login when a key (typically an
+// email address) has exceeded its failed login attempt budget.
+type ErrAccountLocked struct {
+	// RetryAfter is how long the caller should wait before trying again.
+	RetryAfter time.Duration
+}
+
+func (e *ErrAccountLocked) Error() string {
+	return fmt.Sprintf("account temporarily locked; retry after %s", e.RetryAfter)
+}
+
+// LoginAttemptRecorder tracks failed login attempts per key so that repeated
+// failures trigger a temporary lockout, independent of the per-IP rate
+// limiting applied at the HTTP layer.
+type LoginAttemptRecorder interface {
+	// Allow reports whether a login attempt for key may proceed right now.
+	// When it may not, it also reports how long the caller should wait.
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+	// RecordFailure records a failed login attempt for key.
+	RecordFailure(ctx context.Context, key string) error
+	// RecordSuccess clears any recorded failures for key.
+	RecordSuccess(ctx context.Context, key string) error
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// inMemoryLoginAttemptRecorder is a process-local LoginAttemptRecorder. It
+// locks a key out for window once maxAttempts failures have been recorded
+// inside a single window.
+type inMemoryLoginAttemptRecorder struct {
+	mu          sync.Mutex
+	states      map[string]*attemptState
+	maxAttempts int
+	window      time.Duration
+}
+
+type attemptState struct {
+	count       int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+// NewInMemoryLoginAttemptRecorder returns a LoginAttemptRecorder that locks a
+// key out for window once it has accrued maxAttempts failures within window.
+func NewInMemoryLoginAttemptRecorder(maxAttempts int, window time.Duration) LoginAttemptRecorder {
+	return &inMemoryLoginAttemptRecorder{
+		states:      make(map[string]*attemptState),
+		maxAttempts: maxAttempts,
+		window:      window,
+	}
+}
+
+func (r *inMemoryLoginAttemptRecorder) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st, ok := r.states[key]
+	if !ok {
+		return true, 0, nil
+	}
+
+	now := time.Now()
+	if now.Before(st.lockedUntil) {
+		return false, st.lockedUntil.Sub(now), nil
+	}
+
+	return true, 0, nil
+}
+
+func (r *inMemoryLoginAttemptRecorder) RecordFailure(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	st, ok := r.states[key]
+	if !ok || now.Sub(st.windowStart) > r.window {
+		st = &attemptState{windowStart: now}
+		r.states[key] = st
+	}
+
+	st.count++
+	if st.count >= r.maxAttempts {
+		st.lockedUntil = now.Add(r.window)
+	}
+
+	return nil
+}
+
+func (r *inMemoryLoginAttemptRecorder) RecordSuccess(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.states, key)
+	return nil
+}
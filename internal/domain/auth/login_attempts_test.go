@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryLoginAttemptRecorder_LocksOutAfterMaxAttempts(t *testing.T) {
+	recorder := NewInMemoryLoginAttemptRecorder(2, time.Minute)
+	ctx := context.Background()
+
+	allowed, _, err := recorder.Allow(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	require.NoError(t, recorder.RecordFailure(ctx, "user@example.com"))
+
+	allowed, _, err = recorder.Allow(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.True(t, allowed, "one failure should not yet lock out")
+
+	require.NoError(t, recorder.RecordFailure(ctx, "user@example.com"))
+
+	allowed, retryAfter, err := recorder.Allow(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.False(t, allowed, "second failure should trip lockout")
+	assert.Greater(t, retryAfter, time.Duration(0))
+}
+
+func TestInMemoryLoginAttemptRecorder_RecordSuccessClearsState(t *testing.T) {
+	recorder := NewInMemoryLoginAttemptRecorder(1, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, recorder.RecordFailure(ctx, "user@example.com"))
+
+	allowed, _, err := recorder.Allow(ctx, "user@example.com")
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	require.NoError(t, recorder.RecordSuccess(ctx, "user@example.com"))
+
+	allowed, _, err = recorder.Allow(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.True(t, allowed, "a recorded success should clear the lockout")
+}
+
+func TestInMemoryLoginAttemptRecorder_TracksKeysIndependently(t *testing.T) {
+	recorder := NewInMemoryLoginAttemptRecorder(1, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, recorder.RecordFailure(ctx, "a@example.com"))
+
+	allowedA, _, err := recorder.Allow(ctx, "a@example.com")
+	require.NoError(t, err)
+	assert.False(t, allowedA)
+
+	allowedB, _, err := recorder.Allow(ctx, "b@example.com")
+	require.NoError(t, err)
+	assert.True(t, allowedB, "a different key should be unaffected")
+}
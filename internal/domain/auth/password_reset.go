@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken represents a persisted, single-use token authorizing a
+// password reset.
+type PasswordResetToken struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	HashedToken string
+	ExpiresAt   time.Time
+	ConsumedAt  *time.Time
+	CreatedAt   time.Time
+}
+
+// CreatePasswordResetTokenParams contains parameters for persisting a new
+// password reset token
+type CreatePasswordResetTokenParams struct {
+	UserID      uuid.UUID
+	HashedToken string
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"splitwise-clone/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PasswordResetRepository defines the interface for password reset token
+// persistence
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, params CreatePasswordResetTokenParams) (*PasswordResetToken, error)
+	GetPasswordResetTokenByHash(ctx context.Context, hashedToken string) (*PasswordResetToken, error)
+	ConsumePasswordResetToken(ctx context.Context, id uuid.UUID) error
+}
+
+// passwordResetRepository implements the PasswordResetRepository interface
+type passwordResetRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPasswordResetRepository creates a new password reset repository instance
+func NewPasswordResetRepository(db *pgxpool.Pool) PasswordResetRepository {
+	return &passwordResetRepository{
+		db: db,
+	}
+}
+
+// CreatePasswordResetToken inserts a new password reset token into the database
+func (r *passwordResetRepository) CreatePasswordResetToken(ctx context.Context, params CreatePasswordResetTokenParams) (*PasswordResetToken, error) {
+	log := logger.FromContext(ctx)
+
+	query := `
+		INSERT INTO password_reset_tokens (user_id, hashed_token, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, user_id, hashed_token, expires_at, consumed_at, created_at
+	`
+
+	var t PasswordResetToken
+	err := querierFromContext(ctx, r.db).QueryRow(ctx, query, params.UserID, params.HashedToken, params.ExpiresAt).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.HashedToken,
+		&t.ExpiresAt,
+		&t.ConsumedAt,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		log.Error("Failed to insert password reset token", "error", err, "user_id", params.UserID.String())
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// GetPasswordResetTokenByHash retrieves a password reset token by its hashed value
+func (r *passwordResetRepository) GetPasswordResetTokenByHash(ctx context.Context, hashedToken string) (*PasswordResetToken, error) {
+	log := logger.FromContext(ctx)
+
+	query := `
+		SELECT id, user_id, hashed_token, expires_at, consumed_at, created_at
+		FROM password_reset_tokens
+		WHERE hashed_token = $1
+	`
+
+	var t PasswordResetToken
+	err := r.db.QueryRow(ctx, query, hashedToken).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.HashedToken,
+		&t.ExpiresAt,
+		&t.ConsumedAt,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		log.Debug("Password reset token not found or query failed", "error", err)
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// ConsumePasswordResetToken marks a password reset token as used
+func (r *passwordResetRepository) ConsumePasswordResetToken(ctx context.Context, id uuid.UUID) error {
+	log := logger.FromContext(ctx)
+
+	query := `UPDATE password_reset_tokens SET consumed_at = now() WHERE id = $1`
+
+	if _, err := querierFromContext(ctx, r.db).Exec(ctx, query, id); err != nil {
+		log.Error("Failed to consume password reset token", "error", err, "token_id", id.String())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"splitwise-clone/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RefreshTokenRepository defines the interface for refresh token persistence
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, params CreateRefreshTokenParams) (*RefreshToken, error)
+	GetRefreshTokenByHash(ctx context.Context, hashedToken string) (*RefreshToken, error)
+	GetRefreshTokenByID(ctx context.Context, id uuid.UUID) (*RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id uuid.UUID, replacedBy *uuid.UUID) error
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// refreshTokenRepository implements the RefreshTokenRepository interface
+type refreshTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository instance
+func NewRefreshTokenRepository(db *pgxpool.Pool) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+// CreateRefreshToken inserts a new refresh token into the database
+func (r *refreshTokenRepository) CreateRefreshToken(ctx context.Context, params CreateRefreshTokenParams) (*RefreshToken, error) {
+	log := logger.FromContext(ctx)
+
+	query := `
+		INSERT INTO refresh_tokens (user_id, hashed_token, issued_at, expires_at)
+		VALUES ($1, $2, now(), $3)
+		RETURNING id, user_id, hashed_token, issued_at, expires_at, revoked_at, replaced_by
+	`
+
+	log.Debug("Executing CreateRefreshToken query", "user_id", params.UserID.String())
+
+	var rt RefreshToken
+	err := r.db.QueryRow(ctx, query, params.UserID, params.HashedToken, params.ExpiresAt).Scan(
+		&rt.ID,
+		&rt.UserID,
+		&rt.HashedToken,
+		&rt.IssuedAt,
+		&rt.ExpiresAt,
+		&rt.RevokedAt,
+		&rt.ReplacedBy,
+	)
+	if err != nil {
+		log.Error("Failed to insert refresh token", "error", err, "user_id", params.UserID.String())
+		return nil, err
+	}
+
+	return &rt, nil
+}
+
+// GetRefreshTokenByHash retrieves a refresh token by its hashed value
+func (r *refreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, hashedToken string) (*RefreshToken, error) {
+	log := logger.FromContext(ctx)
+
+	query := `
+		SELECT id, user_id, hashed_token, issued_at, expires_at, revoked_at, replaced_by
+		FROM refresh_tokens
+		WHERE hashed_token = $1
+	`
+
+	var rt RefreshToken
+	err := r.db.QueryRow(ctx, query, hashedToken).Scan(
+		&rt.ID,
+		&rt.UserID,
+		&rt.HashedToken,
+		&rt.IssuedAt,
+		&rt.ExpiresAt,
+		&rt.RevokedAt,
+		&rt.ReplacedBy,
+	)
+	if err != nil {
+		log.Debug("Refresh token not found or query failed", "error", err)
+		return nil, err
+	}
+
+	return &rt, nil
+}
+
+// GetRefreshTokenByID retrieves a refresh token by its ID, used to check
+// whether the access token that shares its jti has been revoked
+func (r *refreshTokenRepository) GetRefreshTokenByID(ctx context.Context, id uuid.UUID) (*RefreshToken, error) {
+	log := logger.FromContext(ctx)
+
+	query := `
+		SELECT id, user_id, hashed_token, issued_at, expires_at, revoked_at, replaced_by
+		FROM refresh_tokens
+		WHERE id = $1
+	`
+
+	var rt RefreshToken
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&rt.ID,
+		&rt.UserID,
+		&rt.HashedToken,
+		&rt.IssuedAt,
+		&rt.ExpiresAt,
+		&rt.RevokedAt,
+		&rt.ReplacedBy,
+	)
+	if err != nil {
+		log.Debug("Refresh token not found or query failed", "error", err, "refresh_token_id", id.String())
+		return nil, err
+	}
+
+	return &rt, nil
+}
+
+// RevokeRefreshToken marks a refresh token as revoked, optionally recording
+// the token that replaced it
+func (r *refreshTokenRepository) RevokeRefreshToken(ctx context.Context, id uuid.UUID, replacedBy *uuid.UUID) error {
+	log := logger.FromContext(ctx)
+
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = now(), replaced_by = $2
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, replacedBy); err != nil {
+		log.Error("Failed to revoke refresh token", "error", err, "refresh_token_id", id.String())
+		return err
+	}
+
+	return nil
+}
+
+// RevokeAllForUser revokes every active refresh token belonging to a user,
+// used to kill an entire token family when reuse is detected
+func (r *refreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	log := logger.FromContext(ctx)
+
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		log.Error("Failed to revoke refresh token family", "error", err, "user_id", userID.String())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken represents a persisted refresh token record used for
+// rotation and reuse detection.
+type RefreshToken struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	HashedToken string
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+	RevokedAt   *time.Time
+	ReplacedBy  *uuid.UUID
+}
+
+// CreateRefreshTokenParams contains parameters for persisting a new refresh token
+type CreateRefreshTokenParams struct {
+	UserID      uuid.UUID
+	HashedToken string
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"splitwise-clone/internal/domain/user"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCommandRepository_CreateUser verifies the command side writes a user
+// row and scans back the generated fields.
+func TestCommandRepository_CreateUser(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := &commandRepository{db: pool}
+
+	params := user.CreateUserParams{
+		FirstName:    "John",
+		LastName:     "Doe",
+		Email:        "john.doe@example.com",
+		PasswordHash: "$2a$12$hashedpassword",
+	}
+
+	now := time.Now()
+	rows := pgxmock.NewRows([]string{
+		"id", "first_name", "last_name", "date_of_birth", "email", "password_hash", "phone_number", "email_verified_at", "created_at", "updated_at", "deleted_at",
+	}).AddRow(uuid.New(), params.FirstName, params.LastName, params.DateOfBirth, params.Email, params.PasswordHash, params.PhoneNumber, (*time.Time)(nil), now, now, (*time.Time)(nil))
+
+	pool.ExpectQuery("INSERT INTO users").
+		WithArgs(params.FirstName, params.LastName, params.DateOfBirth, params.Email, params.PasswordHash, params.PhoneNumber).
+		WillReturnRows(rows)
+
+	result, err := repo.CreateUser(context.Background(), params)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, params.Email, result.Email)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+// TestCommandRepository_CreateUser_UsesTxFromContext verifies that a
+// transaction placed in ctx by TxManager.WithTransaction is used instead of
+// the pool, so CreateUser composes atomically with other command writes.
+func TestCommandRepository_CreateUser_UsesTxFromContext(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := &commandRepository{db: pool}
+	params := user.CreateUserParams{FirstName: "Jane", LastName: "Roe", Email: "jane.roe@example.com", PasswordHash: "hash"}
+
+	pool.ExpectBegin()
+	rows := pgxmock.NewRows([]string{
+		"id", "first_name", "last_name", "date_of_birth", "email", "password_hash", "phone_number", "email_verified_at", "created_at", "updated_at", "deleted_at",
+	}).AddRow(uuid.New(), params.FirstName, params.LastName, params.DateOfBirth, params.Email, params.PasswordHash, params.PhoneNumber, (*time.Time)(nil), time.Now(), time.Now(), (*time.Time)(nil))
+	pool.ExpectQuery("INSERT INTO users").
+		WithArgs(params.FirstName, params.LastName, params.DateOfBirth, params.Email, params.PasswordHash, params.PhoneNumber).
+		WillReturnRows(rows)
+	pool.ExpectCommit()
+
+	txManager := NewTxManager(pool)
+	err = txManager.WithTransaction(context.Background(), func(ctx context.Context) error {
+		_, err := repo.CreateUser(ctx, params)
+		return err
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+// TestQueryRepository_GetUserByEmail verifies the read side never needs a
+// transaction and always queries through the pool directly.
+func TestQueryRepository_GetUserByEmail(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := &queryRepository{db: pool}
+
+	rows := pgxmock.NewRows([]string{
+		"id", "first_name", "last_name", "date_of_birth", "email", "password_hash", "phone_number", "email_verified_at", "created_at", "updated_at", "deleted_at",
+	}).AddRow(uuid.New(), "John", "Doe", nil, "john.doe@example.com", "hash", nil, (*time.Time)(nil), time.Now(), time.Now(), (*time.Time)(nil))
+
+	pool.ExpectQuery("SELECT (.+) FROM users").
+		WithArgs("john.doe@example.com").
+		WillReturnRows(rows)
+
+	result, err := repo.GetUserByEmail(context.Background(), "john.doe@example.com")
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, "john.doe@example.com", result.Email)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+// TestTxManager_WithTransaction_CommitsOnSuccess verifies a successful fn
+// commits the transaction.
+func TestTxManager_WithTransaction_CommitsOnSuccess(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	txManager := NewTxManager(pool)
+	err = txManager.WithTransaction(context.Background(), func(ctx context.Context) error {
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+// TestTxManager_WithTransaction_RollsBackOnError verifies fn's error rolls
+// the transaction back instead of committing it.
+func TestTxManager_WithTransaction_RollsBackOnError(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectRollback()
+
+	txManager := NewTxManager(pool)
+	fnErr := errors.New("link failed")
+	err = txManager.WithTransaction(context.Background(), func(ctx context.Context) error {
+		return fnErr
+	})
+
+	assert.ErrorIs(t, err, fnErr)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
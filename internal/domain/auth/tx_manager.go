@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx, letting command
+// repositories run the same query against either a plain connection or an
+// in-flight transaction.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
+// txContextKey is the context key a TxManager uses to thread its pgx.Tx
+// through nested command handlers.
+type txContextKey struct{}
+
+// pgxPool is the subset of *pgxpool.Pool that TxManager and the command/query
+// repositories depend on. It exists so tests can substitute a pgxmock pool
+// instead of a real database; *pgxpool.Pool satisfies it as-is.
+type pgxPool interface {
+	querier
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+var _ pgxPool = (*pgxpool.Pool)(nil)
+
+// Transactor runs fn with a context that command repositories can pull a
+// shared transaction out of. It's an interface, rather than *TxManager
+// directly, so the service layer can be tested without a real database.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// TxManager wraps a sequence of command repository calls in a single
+// database transaction, so e.g. creating a user and linking a federated
+// identity either both succeed or both roll back.
+type TxManager struct {
+	pool pgxPool
+}
+
+// NewTxManager creates a TxManager backed by pool.
+func NewTxManager(pool pgxPool) *TxManager {
+	return &TxManager{pool: pool}
+}
+
+// WithTransaction begins a transaction, runs fn with a context carrying it,
+// and commits on success or rolls back if fn returns an error. Command
+// repositories pull the transaction back out of ctx via querierFromContext,
+// so any number of writes inside fn are applied atomically.
+func (m *TxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	txCtx := context.WithValue(ctx, txContextKey{}, tx)
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// querierFromContext returns the in-flight transaction carried in ctx by
+// TxManager.WithTransaction, falling back to pool when there is none.
+func querierFromContext(ctx context.Context, pool querier) querier {
+	if tx, ok := ctx.Value(txContextKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
@@ -0,0 +1,129 @@
+package grpcapi
+
+import (
+	"context"
+	"runtime/debug"
+	"splitwise-clone/internal/domain/auth"
+	"splitwise-clone/internal/domain/user"
+	"splitwise-clone/internal/logger"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authenticatedMethods lists the full gRPC method names that require a
+// valid access token, mirroring the REST router's RequireAuth-protected
+// route group.
+var authenticatedMethods = map[string]bool{
+	"/splitwise.auth.v1.AuthService/GetUser": true,
+}
+
+// TraceIDUnaryInterceptor injects a trace ID into the request context,
+// taken from the "x-trace-id" metadata key if present, otherwise generated,
+// mirroring router.TraceIDMiddleware.
+func TraceIDUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	traceID := firstMetadataValue(ctx, "x-trace-id")
+	if traceID == "" {
+		traceID = uuid.New().String()
+	}
+
+	ctx = logger.WithTraceID(ctx, traceID)
+	ctx = logger.WithRequestID(ctx, traceID)
+
+	return handler(ctx, req)
+}
+
+// LoggingUnaryInterceptor logs each RPC call with its trace ID, method, and
+// duration, mirroring router.LoggingMiddleware.
+func LoggingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	log := logger.FromContext(ctx)
+
+	log.Info("gRPC request started", "method", info.FullMethod)
+
+	resp, err := handler(ctx, req)
+
+	duration := time.Since(start)
+	log.Info("gRPC request completed",
+		"method", info.FullMethod,
+		"duration", duration,
+		"duration_ms", duration.Milliseconds(),
+		"error", err,
+	)
+
+	return resp, err
+}
+
+// RecoveryUnaryInterceptor recovers from panics in handler, logging them and
+// returning codes.Internal instead of crashing the server, mirroring
+// router.RecoveryMiddleware.
+func RecoveryUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.FromContext(ctx).Error("Panic recovered",
+				"error", r,
+				"stack", string(debug.Stack()),
+				"method", info.FullMethod,
+			)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// AuthUnaryInterceptor validates the "authorization: Bearer <jwt>" metadata
+// for methods listed in authenticatedMethods, injecting the authenticated
+// user into the context on success, mirroring router.RequireAuth.
+func AuthUnaryInterceptor(authService auth.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !authenticatedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		token := bearerToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "missing or malformed authorization metadata")
+		}
+
+		claims, err := authService.ValidateToken(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+
+		ctx = logger.WithUserID(ctx, claims.UserID.String())
+		ctx = auth.WithUser(ctx, &user.User{ID: claims.UserID})
+
+		return handler(ctx, req)
+	}
+}
+
+// bearerToken extracts the token from a "Bearer <token>" authorization
+// metadata value, returning "" if absent or malformed.
+func bearerToken(ctx context.Context) string {
+	header := firstMetadataValue(ctx, "authorization")
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return ""
+	}
+	return parts[1]
+}
+
+// firstMetadataValue returns the first value for key in ctx's incoming
+// metadata, or "" if absent.
+func firstMetadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
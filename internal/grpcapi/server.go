@@ -0,0 +1,196 @@
+// Package grpcapi exposes the auth service over gRPC, alongside the existing
+// REST handlers in internal/httpapi, for internal callers that want a
+// typed, high-throughput alternative to JSON over HTTP.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"splitwise-clone/internal/domain/auth"
+	"splitwise-clone/internal/domain/user"
+	"splitwise-clone/internal/grpcapi/authpb"
+	"splitwise-clone/internal/httpapi/validation"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// signUpRequest and loginRequest mirror the validation rules of the REST
+// dto.SignUpRequest/dto.LoginRequest so incoming gRPC requests get the same
+// checks before reaching auth.Service, since authpb's generated types don't
+// carry validate tags of their own.
+type signUpRequest struct {
+	FirstName   string  `json:"first_name" validate:"required,min=2,max=100"`
+	LastName    string  `json:"last_name" validate:"required,min=2,max=100"`
+	Email       string  `json:"email" validate:"required,email"`
+	Password    string  `json:"password" validate:"required,min=8,max=72"`
+	PhoneNumber *string `json:"phone_number" validate:"omitempty,e164"`
+}
+
+type loginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// invalidArgumentError turns a validation.Struct failure into a stable,
+// human-readable message for the InvalidArgument status, using the same
+// field messages the REST handlers return in dto.ErrorResponse.Details.
+func invalidArgumentError(err error) error {
+	details := validation.Translate(err)
+	if details == nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	fields := make([]string, 0, len(details))
+	for field := range details {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s %v", field, details[field]))
+	}
+
+	return status.Error(codes.InvalidArgument, strings.Join(messages, "; "))
+}
+
+// AuthServer implements authpb.AuthServiceServer on top of auth.Service.
+// app.NewGRPCServer builds that service itself, from the same database pool
+// and config as the REST router, so it is a separate instance from the one
+// behind the REST auth handlers and does not share its in-memory login
+// attempt state.
+type AuthServer struct {
+	authpb.UnimplementedAuthServiceServer
+	authService auth.Service
+}
+
+// NewAuthServer creates a new AuthServer instance
+func NewAuthServer(authService auth.Service) *AuthServer {
+	return &AuthServer{authService: authService}
+}
+
+// SignUp handles user registration requests
+func (s *AuthServer) SignUp(ctx context.Context, req *authpb.SignUpRequest) (*authpb.User, error) {
+	validated := signUpRequest{
+		FirstName: req.GetFirstName(),
+		LastName:  req.GetLastName(),
+		Email:     req.GetEmail(),
+		Password:  req.GetPassword(),
+	}
+	if req.PhoneNumber != nil {
+		phoneNumber := req.GetPhoneNumber()
+		validated.PhoneNumber = &phoneNumber
+	}
+	if err := validation.Struct(validated); err != nil {
+		return nil, invalidArgumentError(err)
+	}
+
+	params := auth.SignUpParams{
+		FirstName:   req.GetFirstName(),
+		LastName:    req.GetLastName(),
+		Email:       req.GetEmail(),
+		Password:    req.GetPassword(),
+		PhoneNumber: validated.PhoneNumber,
+	}
+	if req.DateOfBirth != nil {
+		dateOfBirth := req.GetDateOfBirth().AsTime()
+		params.DateOfBirth = &dateOfBirth
+	}
+
+	u, err := s.authService.SignUp(ctx, params)
+	if err != nil {
+		if errors.Is(err, auth.ErrUserAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to create user")
+	}
+
+	return toPBUser(u), nil
+}
+
+// Login handles user authentication requests
+func (s *AuthServer) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.LoginResponse, error) {
+	validated := loginRequest{
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	}
+	if err := validation.Struct(validated); err != nil {
+		return nil, invalidArgumentError(err)
+	}
+
+	result, err := s.authService.Login(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		var locked *auth.ErrAccountLocked
+		if errors.As(err, &locked) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if errors.Is(err, auth.ErrUserNotFound) || errors.Is(err, auth.ErrInvalidPassword) {
+			return nil, status.Error(codes.Unauthenticated, "invalid email or password")
+		}
+		return nil, status.Error(codes.Internal, "failed to log in")
+	}
+
+	return &authpb.LoginResponse{
+		Tokens: toPBTokenPair(&result.TokenPair),
+		User:   toPBUser(result.User),
+	}, nil
+}
+
+// Refresh handles access token refresh requests
+func (s *AuthServer) Refresh(ctx context.Context, req *authpb.RefreshRequest) (*authpb.TokenPair, error) {
+	pair, err := s.authService.RefreshToken(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired refresh token")
+	}
+
+	return toPBTokenPair(pair), nil
+}
+
+// GetUser returns the profile of the currently authenticated user. The
+// caller is identified by the access token validated in AuthUnaryInterceptor,
+// not by req.user_id, so any mismatch is ignored.
+func (s *AuthServer) GetUser(ctx context.Context, req *authpb.GetUserRequest) (*authpb.User, error) {
+	authedUser, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	u, err := s.authService.GetUserByID(ctx, authedUser.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load user")
+	}
+
+	return toPBUser(u), nil
+}
+
+func toPBUser(u *user.User) *authpb.User {
+	pbUser := &authpb.User{
+		Id:        u.ID.String(),
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Email:     u.Email,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+	}
+	if u.PhoneNumber != nil {
+		phoneNumber := *u.PhoneNumber
+		pbUser.PhoneNumber = &phoneNumber
+	}
+	if u.DateOfBirth != nil {
+		pbUser.DateOfBirth = timestamppb.New(*u.DateOfBirth)
+	}
+	return pbUser
+}
+
+func toPBTokenPair(pair *auth.TokenPair) *authpb.TokenPair {
+	return &authpb.TokenPair{
+		AccessToken:           pair.AccessToken,
+		AccessTokenExpiresAt:  timestamppb.New(pair.AccessTokenExpiresAt),
+		RefreshToken:          pair.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(pair.RefreshTokenExpiresAt),
+	}
+}
@@ -29,9 +29,29 @@ type LoginRequest struct {
 
 // LoginResponse represents the response after successful login
 type LoginResponse struct {
-	Token     string    `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	ExpiresAt time.Time `json:"expires_at" example:"2025-12-31T23:59:59Z"`
-	User      UserInfo  `json:"user"`
+	Token            string    `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt        time.Time `json:"expires_at" example:"2025-12-31T23:59:59Z"`
+	RefreshToken     string    `json:"refresh_token" example:"3nQf9x...opaque-token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at" example:"2026-01-30T23:59:59Z"`
+	User             UserInfo  `json:"user"`
+}
+
+// RefreshRequest represents the request body for refreshing an access token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// RefreshResponse represents the response after a successful token refresh
+type RefreshResponse struct {
+	Token            string    `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt        time.Time `json:"expires_at" example:"2025-12-31T23:59:59Z"`
+	RefreshToken     string    `json:"refresh_token" example:"3nQf9x...opaque-token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at" example:"2026-01-30T23:59:59Z"`
+}
+
+// LogoutRequest represents the request body for logging out
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 // UserInfo represents basic user information
@@ -42,6 +62,31 @@ type UserInfo struct {
 	Email     string `json:"email" example:"john.doe@example.com"`
 }
 
+// ResendVerificationRequest represents the request body for requesting
+// another email verification link
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email" example:"john.doe@example.com"`
+}
+
+// ConfirmEmailRequest represents the request body for confirming an email
+// verification token
+type ConfirmEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// RequestPasswordResetRequest represents the request body for requesting a
+// password reset link
+type RequestPasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email" example:"john.doe@example.com"`
+}
+
+// ResetPasswordRequest represents the request body for completing a
+// password reset
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=72" example:"N3wP@ssw0rd"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string                 `json:"error" example:"Bad Request"`
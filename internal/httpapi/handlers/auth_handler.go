@@ -2,50 +2,48 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"splitwise-clone/internal/domain/auth"
 	"splitwise-clone/internal/httpapi/dto"
+	"splitwise-clone/internal/httpapi/validation"
 	"splitwise-clone/internal/logger"
-
-	"github.com/go-playground/validator/v10"
+	"strconv"
 )
 
 // AuthHandler handles authentication-related HTTP requests
 type AuthHandler struct {
 	authService auth.Service
-	validate    *validator.Validate
 }
 
 // NewAuthHandler creates a new AuthHandler instance
 func NewAuthHandler(authService auth.Service) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
-		validate:    validator.New(),
 	}
 }
 
 // SignUp handles user registration requests
 func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
-	// Get logger from context (includes trace ID)
-	log := logger.FromContext(r.Context())
+	ctx := r.Context()
 
 	var req dto.SignUpRequest
 
 	// Decode request body
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Error().Err(err).Msg("Failed to decode signup request")
+		logger.L().ErrorContext(ctx, "Failed to decode signup request", "error", err)
 		respondWithError(w, http.StatusBadRequest, "Invalid request body", nil)
 		return
 	}
 
 	// Validate request
-	if err := h.validate.Struct(req); err != nil {
-		log.Warn().Err(err).Str("email", req.Email).Msg("Validation failed for signup request")
-		respondWithError(w, http.StatusBadRequest, "Validation failed", parseValidationErrors(err))
+	if err := validation.Struct(req); err != nil {
+		logger.L().WarnContext(ctx, "Validation failed for signup request", "error", err, "email", req.Email)
+		respondWithError(w, http.StatusUnprocessableEntity, "Validation failed", validation.Translate(err))
 		return
 	}
 
-	log.Info().Str("email", req.Email).Msg("Processing signup request")
+	logger.L().InfoContext(ctx, "Processing signup request", "email", req.Email)
 
 	// Call service to create user
 	signUpParams := auth.SignUpParams{
@@ -57,25 +55,22 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 		PhoneNumber: req.PhoneNumber,
 	}
 
-	user, err := h.authService.SignUp(r.Context(), signUpParams)
+	user, err := h.authService.SignUp(ctx, signUpParams)
 	if err != nil {
 		if err == auth.ErrUserAlreadyExists {
-			log.Warn().Str("email", req.Email).Msg("Signup failed: user already exists")
+			logger.L().WarnContext(ctx, "Signup failed: user already exists", "email", req.Email)
 			respondWithError(w, http.StatusConflict, "User with this email already exists", nil)
 			return
 		}
 
 		// Note: Unique constraint violations are already handled by ErrUserAlreadyExists check above
 
-		log.Error().Err(err).Str("email", req.Email).Msg("Failed to create user")
+		logger.L().ErrorContext(ctx, "Failed to create user", "error", err, "email", req.Email)
 		respondWithError(w, http.StatusInternalServerError, "Failed to create user", nil)
 		return
 	}
 
-	log.Info().
-		Str("user_id", user.ID.String()).
-		Str("email", user.Email).
-		Msg("User created successfully")
+	logger.L().InfoContext(ctx, "User created successfully", "user_id", user.ID.String(), "email", user.Email)
 
 	// Build response
 	response := dto.SignUpResponse{
@@ -89,6 +84,228 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusCreated, response)
 }
 
+// Login handles user authentication requests
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.LoginRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to decode login request", "error", err)
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := validation.Struct(req); err != nil {
+		logger.L().WarnContext(ctx, "Validation failed for login request", "error", err, "email", req.Email)
+		respondWithError(w, http.StatusUnprocessableEntity, "Validation failed", validation.Translate(err))
+		return
+	}
+
+	result, err := h.authService.Login(ctx, req.Email, req.Password)
+	if err != nil {
+		var locked *auth.ErrAccountLocked
+		if errors.As(err, &locked) {
+			logger.L().WarnContext(ctx, "Login failed: account temporarily locked", "email", req.Email, "retry_after", locked.RetryAfter)
+			w.Header().Set("Retry-After", strconv.Itoa(int(locked.RetryAfter.Seconds())))
+			respondWithError(w, http.StatusTooManyRequests, "Too many failed login attempts", nil)
+			return
+		}
+
+		if err == auth.ErrUserNotFound || err == auth.ErrInvalidPassword {
+			logger.L().WarnContext(ctx, "Login failed: invalid credentials", "email", req.Email)
+			respondWithError(w, http.StatusUnauthorized, "Invalid email or password", nil)
+			return
+		}
+
+		logger.L().ErrorContext(ctx, "Failed to log in user", "error", err, "email", req.Email)
+		respondWithError(w, http.StatusInternalServerError, "Failed to log in", nil)
+		return
+	}
+
+	logger.L().InfoContext(ctx, "User logged in successfully", "user_id", result.User.ID.String())
+
+	response := dto.LoginResponse{
+		Token:            result.AccessToken,
+		ExpiresAt:        result.AccessTokenExpiresAt,
+		RefreshToken:     result.RefreshToken,
+		RefreshExpiresAt: result.RefreshTokenExpiresAt,
+		User: dto.UserInfo{
+			ID:        result.User.ID.String(),
+			FirstName: result.User.FirstName,
+			LastName:  result.User.LastName,
+			Email:     result.User.Email,
+		},
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// RefreshToken handles access token refresh requests
+func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.RefreshRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to decode refresh request", "error", err)
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := validation.Struct(req); err != nil {
+		logger.L().WarnContext(ctx, "Validation failed for refresh request", "error", err)
+		respondWithError(w, http.StatusUnprocessableEntity, "Validation failed", validation.Translate(err))
+		return
+	}
+
+	pair, err := h.authService.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		logger.L().WarnContext(ctx, "Refresh token request failed", "error", err)
+		respondWithError(w, http.StatusUnauthorized, "Invalid or expired refresh token", nil)
+		return
+	}
+
+	response := dto.RefreshResponse{
+		Token:            pair.AccessToken,
+		ExpiresAt:        pair.AccessTokenExpiresAt,
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: pair.RefreshTokenExpiresAt,
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// Logout handles session termination by revoking the presented refresh token
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.LogoutRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to decode logout request", "error", err)
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := validation.Struct(req); err != nil {
+		logger.L().WarnContext(ctx, "Validation failed for logout request", "error", err)
+		respondWithError(w, http.StatusUnprocessableEntity, "Validation failed", validation.Translate(err))
+		return
+	}
+
+	if err := h.authService.Logout(ctx, req.RefreshToken); err != nil {
+		logger.L().WarnContext(ctx, "Logout failed", "error", err)
+		respondWithError(w, http.StatusUnauthorized, "Invalid or expired refresh token", nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResendVerification handles requests to re-send the email verification link
+func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.ResendVerificationRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to decode resend verification request", "error", err)
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := validation.Struct(req); err != nil {
+		logger.L().WarnContext(ctx, "Validation failed for resend verification request", "error", err, "email", req.Email)
+		respondWithError(w, http.StatusUnprocessableEntity, "Validation failed", validation.Translate(err))
+		return
+	}
+
+	if err := h.authService.SendVerificationEmail(ctx, req.Email); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to resend verification email", "error", err, "email", req.Email)
+		respondWithError(w, http.StatusInternalServerError, "Failed to resend verification email", nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ConfirmEmail handles the link sent by SendVerificationEmail, consuming its
+// token to mark the owning account as verified.
+func (h *AuthHandler) ConfirmEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := dto.ConfirmEmailRequest{Token: r.URL.Query().Get("token")}
+
+	if err := validation.Struct(req); err != nil {
+		logger.L().WarnContext(ctx, "Validation failed for confirm email request", "error", err)
+		respondWithError(w, http.StatusUnprocessableEntity, "Validation failed", validation.Translate(err))
+		return
+	}
+
+	if err := h.authService.ConfirmEmail(ctx, req.Token); err != nil {
+		logger.L().WarnContext(ctx, "Email confirmation failed", "error", err)
+		respondWithError(w, http.StatusUnauthorized, "Invalid or expired verification token", nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestPasswordReset handles requests to send a password reset link
+func (h *AuthHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.RequestPasswordResetRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to decode password reset request", "error", err)
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := validation.Struct(req); err != nil {
+		logger.L().WarnContext(ctx, "Validation failed for password reset request", "error", err, "email", req.Email)
+		respondWithError(w, http.StatusUnprocessableEntity, "Validation failed", validation.Translate(err))
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(ctx, req.Email); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to request password reset", "error", err, "email", req.Email)
+		respondWithError(w, http.StatusInternalServerError, "Failed to request password reset", nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResetPassword handles requests to complete a password reset
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.ResetPasswordRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.L().ErrorContext(ctx, "Failed to decode reset password request", "error", err)
+		respondWithError(w, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := validation.Struct(req); err != nil {
+		logger.L().WarnContext(ctx, "Validation failed for reset password request", "error", err)
+		respondWithError(w, http.StatusUnprocessableEntity, "Validation failed", validation.Translate(err))
+		return
+	}
+
+	if err := h.authService.ResetPassword(ctx, req.Token, req.NewPassword); err != nil {
+		logger.L().WarnContext(ctx, "Password reset failed", "error", err)
+		respondWithError(w, http.StatusUnauthorized, "Invalid or expired password reset token", nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // respondWithError sends an error response
 func respondWithError(w http.ResponseWriter, code int, message string, details map[string]interface{}) {
 	respondWithJSON(w, code, dto.ErrorResponse{
@@ -109,15 +326,3 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 		_ = err // Acknowledge the error but can't do much at this point
 	}
 }
-
-// parseValidationErrors converts validator errors to a map
-func parseValidationErrors(err error) map[string]interface{} {
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		errors := make(map[string]interface{})
-		for _, fieldError := range validationErrors {
-			errors[fieldError.Field()] = fieldError.Tag()
-		}
-		return errors
-	}
-	return nil
-}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+	"splitwise-clone/internal/domain/auth"
+	"splitwise-clone/internal/httpapi/dto"
+	"splitwise-clone/internal/logger"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// stateCookieName is the cookie used to defend the OAuth2 authorization
+// code flow against CSRF by round-tripping a per-attempt random value.
+// pkceVerifierCookieName round-trips the PKCE code verifier the same way,
+// so the authorization code can't be redeemed by anyone who only
+// intercepts it in transit.
+const (
+	stateCookieName        = "oauth_state"
+	pkceVerifierCookieName = "oauth_pkce_verifier"
+)
+
+// FederatedHandler handles OAuth2/OIDC social login requests
+type FederatedHandler struct {
+	authService auth.Service
+	providers   map[string]auth.FederatedProvider
+}
+
+// NewFederatedHandler creates a new FederatedHandler instance
+func NewFederatedHandler(authService auth.Service, providers map[string]auth.FederatedProvider) *FederatedHandler {
+	return &FederatedHandler{
+		authService: authService,
+		providers:   providers,
+	}
+}
+
+// Login redirects the user to the selected provider's authorization URL
+func (h *FederatedHandler) Login(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	provider, ok := h.providers[chi.URLParam(r, "provider")]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "Unknown identity provider", nil)
+		return
+	}
+
+	state := uuid.New().String()
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookieName,
+		Value:    state,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.Now().Add(10 * time.Minute),
+	})
+
+	codeVerifier := oauth2.GenerateVerifier()
+	http.SetCookie(w, &http.Cookie{
+		Name:     pkceVerifierCookieName,
+		Value:    codeVerifier,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.Now().Add(10 * time.Minute),
+	})
+
+	log.Info("Redirecting to federated provider for login", "provider", provider.Name())
+
+	http.Redirect(w, r, provider.AuthCodeURL(state, codeVerifier), http.StatusFound)
+}
+
+// Callback completes the OAuth2 flow, provisioning/logging in the user
+func (h *FederatedHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	provider, ok := h.providers[chi.URLParam(r, "provider")]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "Unknown identity provider", nil)
+		return
+	}
+
+	stateCookie, err := r.Cookie(stateCookieName)
+	if err != nil || stateCookie.Value == "" || stateCookie.Value != r.URL.Query().Get("state") {
+		log.Warn("OAuth state mismatch; possible CSRF attempt", "provider", provider.Name())
+		respondWithError(w, http.StatusBadRequest, "Invalid OAuth state", nil)
+		return
+	}
+
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		respondWithError(w, http.StatusBadRequest, "Missing authorization code", nil)
+		return
+	}
+
+	verifierCookie, err := r.Cookie(pkceVerifierCookieName)
+	if err != nil || verifierCookie.Value == "" {
+		log.Warn("Missing PKCE code verifier cookie", "provider", provider.Name())
+		respondWithError(w, http.StatusBadRequest, "Invalid OAuth state", nil)
+		return
+	}
+
+	identity, err := provider.Exchange(r.Context(), code, verifierCookie.Value)
+	if err != nil {
+		log.Error("Failed to exchange authorization code", "error", err, "provider", provider.Name())
+		respondWithError(w, http.StatusBadGateway, "Failed to complete provider sign-in", nil)
+		return
+	}
+
+	result, err := h.authService.LoginWithFederatedIdentity(r.Context(), identity)
+	if err != nil {
+		log.Error("Failed to authenticate federated identity", "error", err, "provider", provider.Name())
+		respondWithError(w, http.StatusInternalServerError, "Failed to complete sign-in", nil)
+		return
+	}
+
+	log.Info("User authenticated via federated provider", "user_id", result.User.ID.String(), "provider", provider.Name())
+
+	response := dto.LoginResponse{
+		Token:            result.AccessToken,
+		ExpiresAt:        result.AccessTokenExpiresAt,
+		RefreshToken:     result.RefreshToken,
+		RefreshExpiresAt: result.RefreshTokenExpiresAt,
+		User: dto.UserInfo{
+			ID:        result.User.ID.String(),
+			FirstName: result.User.FirstName,
+			LastName:  result.User.LastName,
+			Email:     result.User.Email,
+		},
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
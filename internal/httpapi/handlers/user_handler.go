@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"splitwise-clone/internal/domain/auth"
+	"splitwise-clone/internal/httpapi/dto"
+	"splitwise-clone/internal/logger"
+)
+
+// UserHandler handles user profile HTTP requests
+type UserHandler struct {
+	authService auth.Service
+}
+
+// NewUserHandler creates a new UserHandler instance
+func NewUserHandler(authService auth.Service) *UserHandler {
+	return &UserHandler{
+		authService: authService,
+	}
+}
+
+// Me returns the profile of the currently authenticated user
+func (h *UserHandler) Me(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	authedUser, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Not authenticated", nil)
+		return
+	}
+
+	u, err := h.authService.GetUserByID(r.Context(), authedUser.ID)
+	if err != nil {
+		log.Error("Failed to load current user", "error", err, "user_id", authedUser.ID.String())
+		respondWithError(w, http.StatusInternalServerError, "Failed to load user", nil)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.UserInfo{
+		ID:        u.ID.String(),
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Email:     u.Email,
+	})
+}
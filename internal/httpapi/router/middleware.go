@@ -1,9 +1,19 @@
 package router
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
 	"net/http"
 	"runtime/debug"
+	"splitwise-clone/internal/domain/auth"
+	"splitwise-clone/internal/domain/user"
+	"splitwise-clone/internal/httpapi/dto"
+	"splitwise-clone/internal/limiter"
 	"splitwise-clone/internal/logger"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -48,26 +58,26 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		log := logger.FromContext(r.Context())
 
 		// Log request start
-		log.Info().
-			Str("method", r.Method).
-			Str("path", r.URL.Path).
-			Str("remote_addr", r.RemoteAddr).
-			Str("user_agent", r.UserAgent()).
-			Msg("HTTP request started")
+		log.Info("HTTP request started",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"remote_addr", r.RemoteAddr,
+			"user_agent", r.UserAgent(),
+		)
 
 		next.ServeHTTP(ww, r)
 
 		duration := time.Since(start)
 
 		// Log request completion
-		log.Info().
-			Str("method", r.Method).
-			Str("path", r.URL.Path).
-			Str("remote_addr", r.RemoteAddr).
-			Int("status", ww.statusCode).
-			Dur("duration", duration).
-			Int64("duration_ms", duration.Milliseconds()).
-			Msg("HTTP request completed")
+		log.Info("HTTP request completed",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"remote_addr", r.RemoteAddr,
+			"status", ww.statusCode,
+			"duration", duration,
+			"duration_ms", duration.Milliseconds(),
+		)
 	})
 }
 
@@ -79,12 +89,12 @@ func RecoveryMiddleware(next http.Handler) http.Handler {
 				// Get logger from context
 				log := logger.FromContext(r.Context())
 
-				log.Error().
-					Interface("error", err).
-					Str("stack", string(debug.Stack())).
-					Str("method", r.Method).
-					Str("path", r.URL.Path).
-					Msg("Panic recovered")
+				log.Error("Panic recovered",
+					"error", err,
+					"stack", string(debug.Stack()),
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
 
 				w.WriteHeader(http.StatusInternalServerError)
 				w.Write([]byte(`{"error": "Internal Server Error"}`))
@@ -95,6 +105,152 @@ func RecoveryMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// RequireAuth validates the Authorization: Bearer <jwt> header using
+// authService, rejecting missing/expired/invalid tokens with 401. On
+// success it injects the authenticated user into the request context and
+// populates logger.UserIDKey so logger.FromContext(ctx) includes it in
+// every subsequent log line for the request.
+func RequireAuth(authService auth.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log := logger.FromContext(r.Context())
+
+			header := r.Header.Get("Authorization")
+			parts := strings.SplitN(header, " ", 2)
+			if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || parts[1] == "" {
+				log.Debug("Missing or malformed Authorization header")
+				writeUnauthorized(w, "Missing or malformed Authorization header")
+				return
+			}
+
+			claims, err := authService.ValidateToken(r.Context(), parts[1])
+			if err != nil {
+				log.Debug("Access token validation failed", "error", err)
+				writeUnauthorized(w, "Invalid or expired token")
+				return
+			}
+
+			ctx := logger.WithUserID(r.Context(), claims.UserID.String())
+			ctx = auth.WithUser(ctx, &user.User{ID: claims.UserID})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RateLimitMiddleware throttles requests using store, keyed by keyFunc. Once
+// a key's budget is exhausted it responds 429 with a Retry-After header and
+// a standard dto.ErrorResponse, without calling next.
+func RateLimitMiddleware(store limiter.Store, keyFunc func(r *http.Request) (string, error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log := logger.FromContext(r.Context())
+
+			key, err := keyFunc(r)
+			if err != nil {
+				log.Warn("Failed to derive rate limit key; allowing request", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			allowed, retryAfter, err := store.Allow(r.Context(), key)
+			if err != nil {
+				log.Error("Rate limit store error; allowing request", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !allowed {
+				log.Warn("Request rate limited", "key", key, "retry_after", retryAfter)
+				writeRateLimited(w, retryAfter)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// loginRateLimitKey keys login rate limiting by (remote_ip, email), peeking
+// the request body without consuming it so the handler can still decode it.
+func loginRateLimitKey(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	_ = json.Unmarshal(body, &req)
+
+	return "login:" + remoteIP(r) + ":" + strings.ToLower(req.Email), nil
+}
+
+// signupRateLimitKey keys signup rate limiting by remote_ip alone.
+func signupRateLimitKey(r *http.Request) (string, error) {
+	return "signup:" + remoteIP(r), nil
+}
+
+// emailRateLimitKey keys rate limiting for a prefix by (remote_ip, email),
+// peeking the request body without consuming it so the handler can still
+// decode it. It's shared by the endpoints that trigger an outbound email to
+// a caller-supplied address, to bound email-bombing and enumeration timing.
+func emailRateLimitKey(prefix string) func(r *http.Request) (string, error) {
+	return func(r *http.Request) (string, error) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return "", err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var req struct {
+			Email string `json:"email"`
+		}
+		_ = json.Unmarshal(body, &req)
+
+		return prefix + ":" + remoteIP(r) + ":" + strings.ToLower(req.Email), nil
+	}
+}
+
+// passwordResetConfirmRateLimitKey keys password reset confirmation rate
+// limiting by remote_ip alone, since the request carries a reset token
+// rather than an email address.
+func passwordResetConfirmRateLimitKey(r *http.Request) (string, error) {
+	return "password-reset-confirm:" + remoteIP(r), nil
+}
+
+// remoteIP extracts the client IP from a request's RemoteAddr, stripping the
+// port if present.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// writeRateLimited writes a standard 429 error response with a Retry-After
+// header expressed in whole seconds.
+func writeRateLimited(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(dto.ErrorResponse{
+		Error:   http.StatusText(http.StatusTooManyRequests),
+		Message: "Too many requests",
+	})
+}
+
+// writeUnauthorized writes a standard 401 error response
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(dto.ErrorResponse{
+		Error:   http.StatusText(http.StatusUnauthorized),
+		Message: message,
+	})
+}
+
 // responseWriter is a custom ResponseWriter to capture the status code
 type responseWriter struct {
 	http.ResponseWriter
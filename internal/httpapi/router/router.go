@@ -2,27 +2,55 @@ package router
 
 import (
 	"net/http"
+	"os"
 	"splitwise-clone/internal/domain/auth"
 	"splitwise-clone/internal/httpapi/handlers"
+	"splitwise-clone/internal/limiter"
+	"splitwise-clone/internal/logger"
+	"splitwise-clone/internal/mail"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/rs/zerolog/log"
 
 	_ "splitwise-clone/docs"
 
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
+// Rate limits applied to the auth endpoints most exposed to brute-force and
+// abuse. Login is also protected by auth.LoginAttemptRecorder, which locks
+// out individual accounts regardless of which IP is attempting them.
+// resend-verification and password-reset each trigger an outbound email to
+// a caller-supplied address, so they're rate-limited to bound email-bombing
+// and enumeration timing.
+const (
+	loginRateLimit       = 5
+	loginRateLimitWindow = 15 * time.Minute
+
+	signupRateLimit       = 10
+	signupRateLimitWindow = time.Minute
+
+	resendVerificationRateLimit       = 5
+	resendVerificationRateLimitWindow = 15 * time.Minute
+
+	passwordResetRateLimit       = 5
+	passwordResetRateLimitWindow = 15 * time.Minute
+
+	passwordResetConfirmRateLimit       = 10
+	passwordResetConfirmRateLimitWindow = 15 * time.Minute
+)
+
 // Router holds the HTTP router and its dependencies
 type Router struct {
 	chi.Router
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	authCfg auth.Config
 }
 
 // NewRouter creates and configures a new HTTP router
-func NewRouter(db *pgxpool.Pool) *Router {
+func NewRouter(db *pgxpool.Pool, authCfg auth.Config) *Router {
 	r := chi.NewRouter()
 
 	// Middleware setup (order matters!)
@@ -41,8 +69,9 @@ func NewRouter(db *pgxpool.Pool) *Router {
 	}))
 
 	router := &Router{
-		Router: r,
-		db:     db,
+		Router:  r,
+		db:      db,
+		authCfg: authCfg,
 	}
 
 	router.setupRoutes()
@@ -53,13 +82,22 @@ func NewRouter(db *pgxpool.Pool) *Router {
 // setupRoutes configures all application routes
 func (router *Router) setupRoutes() {
 	// Initialize repositories
-	authRepo := auth.NewRepository(router.db)
+	authCommandRepo := auth.NewCommandRepository(router.db)
+	authQueryRepo := auth.NewQueryRepository(router.db)
+	refreshTokenRepo := auth.NewRefreshTokenRepository(router.db)
+	federatedIdentityRepo := auth.NewFederatedIdentityRepository(router.db)
+	emailVerificationRepo := auth.NewEmailVerificationRepository(router.db)
+	passwordResetRepo := auth.NewPasswordResetRepository(router.db)
+	txManager := auth.NewTxManager(router.db)
 
 	// Initialize services
-	authService := auth.NewService(authRepo)
+	loginAttempts := auth.NewInMemoryLoginAttemptRecorder(loginRateLimit, loginRateLimitWindow)
+	authService := auth.NewService(authCommandRepo, authQueryRepo, refreshTokenRepo, federatedIdentityRepo, emailVerificationRepo, passwordResetRepo, txManager, router.authCfg, loginAttempts, mail.NewFromEnv())
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
+	federatedHandler := handlers.NewFederatedHandler(authService, federatedProviders())
+	userHandler := handlers.NewUserHandler(authService)
 
 	router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("http://localhost:8080/swagger/doc.json"), // The url pointing to API definition
@@ -69,7 +107,7 @@ func (router *Router) setupRoutes() {
 	router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		if _, err := w.Write([]byte("OK")); err != nil {
-			log.Error().Err(err).Msg("Failed to write health check response")
+			logger.L().Error("Failed to write health check response", "error", err)
 		}
 	})
 
@@ -77,18 +115,53 @@ func (router *Router) setupRoutes() {
 	router.Route("/api/v1", func(r chi.Router) {
 		// Auth routes
 		r.Route("/auth", func(r chi.Router) {
-			r.Post("/signup", authHandler.SignUp)
-			r.Post("/login", authHandler.Login)
-			// r.Post("/logout", authHandler.Logout)   // For future implementation
+			signupLimiter := limiter.NewInMemoryStore(signupRateLimit, signupRateLimitWindow)
+			loginLimiter := limiter.NewInMemoryStore(loginRateLimit, loginRateLimitWindow)
+			resendVerificationLimiter := limiter.NewInMemoryStore(resendVerificationRateLimit, resendVerificationRateLimitWindow)
+			passwordResetLimiter := limiter.NewInMemoryStore(passwordResetRateLimit, passwordResetRateLimitWindow)
+			passwordResetConfirmLimiter := limiter.NewInMemoryStore(passwordResetConfirmRateLimit, passwordResetConfirmRateLimitWindow)
+
+			r.With(RateLimitMiddleware(signupLimiter, signupRateLimitKey)).Post("/signup", authHandler.SignUp)
+			r.With(RateLimitMiddleware(loginLimiter, loginRateLimitKey)).Post("/login", authHandler.Login)
+			r.Post("/refresh", authHandler.RefreshToken)
+			r.Post("/logout", authHandler.Logout)
+			r.With(RateLimitMiddleware(resendVerificationLimiter, emailRateLimitKey("resend-verification"))).Post("/resend-verification", authHandler.ResendVerification)
+			r.Get("/verify-email", authHandler.ConfirmEmail)
+			r.With(RateLimitMiddleware(passwordResetLimiter, emailRateLimitKey("password-reset"))).Post("/password-reset", authHandler.RequestPasswordReset)
+			r.With(RateLimitMiddleware(passwordResetConfirmLimiter, passwordResetConfirmRateLimitKey)).Post("/password-reset/confirm", authHandler.ResetPassword)
+
+			r.Route("/oauth/{provider}", func(r chi.Router) {
+				r.Get("/login", federatedHandler.Login)
+				r.Get("/callback", federatedHandler.Callback)
+			})
 		})
 
-		// Protected routes (will need authentication middleware)
-		// r.Group(func(r chi.Router) {
-		// 	r.Use(AuthMiddleware) // Add JWT middleware here
-		// 	r.Get("/users/me", userHandler.GetCurrentUser)
-		// })
+		// Protected routes
+		r.Group(func(r chi.Router) {
+			r.Use(RequireAuth(authService))
+			r.Get("/users/me", userHandler.Me)
+		})
 	})
 
 	// Log registered routes
-	log.Info().Msg("Routes registered successfully")
+	logger.L().Info("Routes registered successfully")
+}
+
+// federatedProviders builds the set of configured OAuth2/OIDC identity
+// providers from environment variables. A provider is only registered once
+// its client ID is set, so deployments can enable providers selectively.
+func federatedProviders() map[string]auth.FederatedProvider {
+	providers := make(map[string]auth.FederatedProvider)
+
+	if clientID := os.Getenv("GOOGLE_CLIENT_ID"); clientID != "" {
+		provider := auth.NewGoogleProvider(clientID, os.Getenv("GOOGLE_CLIENT_SECRET"), os.Getenv("GOOGLE_REDIRECT_URL"))
+		providers[provider.Name()] = provider
+	}
+
+	if clientID := os.Getenv("GITHUB_CLIENT_ID"); clientID != "" {
+		provider := auth.NewGitHubProvider(clientID, os.Getenv("GITHUB_CLIENT_SECRET"), os.Getenv("GITHUB_REDIRECT_URL"))
+		providers[provider.Name()] = provider
+	}
+
+	return providers
 }
@@ -0,0 +1,82 @@
+// Package validation provides a shared go-playground/validator instance for
+// the HTTP layer, along with helpers to translate validation failures into
+// the structured details map used by dto.ErrorResponse.
+package validation
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// e164Pattern matches E.164 phone numbers: a leading '+' followed by two to
+// fifteen digits, the first of which is non-zero.
+var e164Pattern = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+// validate is the shared validator instance used across HTTP handlers.
+var validate *validator.Validate
+
+func init() {
+	validate = validator.New()
+
+	// Report JSON field names instead of Go struct field names so error
+	// details match the wire format the client sent.
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	if err := validate.RegisterValidation("e164", validateE164); err != nil {
+		panic("validation: failed to register e164 validator: " + err.Error())
+	}
+}
+
+// validateE164 reports whether the field value is a valid E.164 phone number.
+func validateE164(fl validator.FieldLevel) bool {
+	return e164Pattern.MatchString(fl.Field().String())
+}
+
+// Struct validates s against its `validate` struct tags.
+func Struct(s interface{}) error {
+	return validate.Struct(s)
+}
+
+// Translate converts a validation error into a map keyed by JSON field name,
+// suitable for dto.ErrorResponse.Details. It returns nil if err is not a
+// validator.ValidationErrors.
+func Translate(err error) map[string]interface{} {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil
+	}
+
+	details := make(map[string]interface{}, len(validationErrors))
+	for _, fe := range validationErrors {
+		details[fe.Field()] = translateFieldError(fe)
+	}
+	return details
+}
+
+// translateFieldError produces a human-readable message for a single field
+// error based on the failed validation tag.
+func translateFieldError(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "e164":
+		return "must be a valid E.164 phone number"
+	case "min":
+		return "must be at least " + fe.Param() + " characters long"
+	case "max":
+		return "must be at most " + fe.Param() + " characters long"
+	default:
+		return "is invalid"
+	}
+}
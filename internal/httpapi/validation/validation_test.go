@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSignUpRequest struct {
+	FirstName   string  `json:"first_name" validate:"required,min=2,max=100"`
+	Email       string  `json:"email" validate:"required,email"`
+	Password    string  `json:"password" validate:"required,min=8,max=72"`
+	PhoneNumber *string `json:"phone_number,omitempty" validate:"omitempty,e164"`
+}
+
+func ptr(s string) *string { return &s }
+
+func TestStruct_MultipleScenarios(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           testSignUpRequest
+		shouldSucceed bool
+		invalidFields []string
+	}{
+		{
+			name: "valid request",
+			req: testSignUpRequest{
+				FirstName: "Jane",
+				Email:     "jane@example.com",
+				Password:  "P@ssw0rd",
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "missing required fields",
+			req: testSignUpRequest{
+				FirstName: "",
+				Email:     "",
+				Password:  "",
+			},
+			shouldSucceed: false,
+			invalidFields: []string{"first_name", "email", "password"},
+		},
+		{
+			name: "first name too short",
+			req: testSignUpRequest{
+				FirstName: "J",
+				Email:     "jane@example.com",
+				Password:  "P@ssw0rd",
+			},
+			shouldSucceed: false,
+			invalidFields: []string{"first_name"},
+		},
+		{
+			name: "password too long",
+			req: testSignUpRequest{
+				FirstName: "Jane",
+				Email:     "jane@example.com",
+				Password:  string(make([]byte, 73)),
+			},
+			shouldSucceed: false,
+			invalidFields: []string{"password"},
+		},
+		{
+			name: "invalid email",
+			req: testSignUpRequest{
+				FirstName: "Jane",
+				Email:     "not-an-email",
+				Password:  "P@ssw0rd",
+			},
+			shouldSucceed: false,
+			invalidFields: []string{"email"},
+		},
+		{
+			name: "invalid e164 phone number",
+			req: testSignUpRequest{
+				FirstName:   "Jane",
+				Email:       "jane@example.com",
+				Password:    "P@ssw0rd",
+				PhoneNumber: ptr("0123"),
+			},
+			shouldSucceed: false,
+			invalidFields: []string{"phone_number"},
+		},
+		{
+			name: "valid e164 phone number",
+			req: testSignUpRequest{
+				FirstName:   "Jane",
+				Email:       "jane@example.com",
+				Password:    "P@ssw0rd",
+				PhoneNumber: ptr("+14155552671"),
+			},
+			shouldSucceed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.req)
+
+			if tt.shouldSucceed {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			details := Translate(err)
+			require.NotNil(t, details)
+			for _, field := range tt.invalidFields {
+				assert.Contains(t, details, field)
+			}
+		})
+	}
+}
+
+func TestTranslate_NonValidationError(t *testing.T) {
+	details := Translate(assert.AnError)
+	assert.Nil(t, details)
+}
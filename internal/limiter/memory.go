@@ -0,0 +1,60 @@
+package limiter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// memoryStore is an in-process token bucket Store. Each key gets its own
+// bucket of capacity tokens, refilled continuously so that limit requests
+// are allowed per window. It does not share state across instances; a
+// Redis-backed Store implementing the same interface can be swapped in for
+// multi-instance deployments.
+type memoryStore struct {
+	mu              sync.Mutex
+	buckets         map[string]*bucket
+	capacity        float64
+	refillPerSecond float64
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// NewInMemoryStore returns a Store that allows up to limit requests per
+// window for each key, refilling gradually rather than all at once at the
+// start of each window.
+func NewInMemoryStore(limit int, window time.Duration) Store {
+	return &memoryStore{
+		buckets:         make(map[string]*bucket),
+		capacity:        float64(limit),
+		refillPerSecond: float64(limit) / window.Seconds(),
+	}
+}
+
+func (s *memoryStore) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: s.capacity, updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.tokens = math.Min(s.capacity, b.tokens+elapsed*s.refillPerSecond)
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		retryAfter := time.Duration((1 - b.tokens) / s.refillPerSecond * float64(time.Second))
+		return false, retryAfter, nil
+	}
+
+	b.tokens--
+	return true, 0, nil
+}
@@ -0,0 +1,62 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryStore_AllowsUpToCapacity(t *testing.T) {
+	store := NewInMemoryStore(3, time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		allowed, _, err := store.Allow(ctx, "key")
+		require.NoError(t, err)
+		assert.True(t, allowed, "request %d should be allowed", i+1)
+	}
+
+	allowed, retryAfter, err := store.Allow(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, allowed, "request beyond capacity should be denied")
+	assert.Greater(t, retryAfter, time.Duration(0))
+}
+
+func TestInMemoryStore_TracksKeysIndependently(t *testing.T) {
+	store := NewInMemoryStore(1, time.Minute)
+	ctx := context.Background()
+
+	allowedA, _, err := store.Allow(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, allowedA)
+
+	allowedB, _, err := store.Allow(ctx, "b")
+	require.NoError(t, err)
+	assert.True(t, allowedB, "a different key should have its own budget")
+
+	allowedA, _, err = store.Allow(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, allowedA, "key a should now be exhausted")
+}
+
+func TestInMemoryStore_RefillsOverTime(t *testing.T) {
+	store := NewInMemoryStore(1, 10*time.Millisecond)
+	ctx := context.Background()
+
+	allowed, _, err := store.Allow(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	allowed, _, err = store.Allow(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	time.Sleep(15 * time.Millisecond)
+
+	allowed, _, err = store.Allow(ctx, "key")
+	require.NoError(t, err)
+	assert.True(t, allowed, "bucket should have refilled after the window elapsed")
+}
@@ -0,0 +1,17 @@
+// Package limiter provides pluggable rate-limiting backends used by the
+// HTTP layer to throttle abusive clients.
+package limiter
+
+import (
+	"context"
+	"time"
+)
+
+// Store decides whether a caller identified by key may proceed right now.
+// Implementations are expected to be safe for concurrent use.
+type Store interface {
+	// Allow reports whether key may proceed, consuming one unit of its
+	// budget if so. When it may not, it also reports how long the caller
+	// should wait before retrying.
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
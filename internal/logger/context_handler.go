@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ContextHandler wraps another slog.Handler, auto-injecting trace_id,
+// request_id, and user_id attributes pulled from the record's context.
+// Callers using the *Context logging methods (e.g. InfoContext) get these
+// fields for free, without rebuilding a logger via FromContext.
+type ContextHandler struct {
+	next slog.Handler
+}
+
+// NewContextHandler wraps next with context-based attribute injection.
+func NewContextHandler(next slog.Handler) *ContextHandler {
+	return &ContextHandler{next: next}
+}
+
+// Enabled reports whether the wrapped handler is enabled for level.
+func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds trace_id, request_id, and user_id attributes found in ctx to
+// record before delegating to the wrapped handler.
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if traceID, ok := ctx.Value(TraceIDKey).(string); ok && traceID != "" {
+		record.AddAttrs(slog.String("trace_id", traceID))
+	}
+	if requestID, ok := ctx.Value(RequestIDKey).(string); ok && requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if userID, ok := ctx.Value(UserIDKey).(string); ok && userID != "" {
+		record.AddAttrs(slog.String("user_id", userID))
+	}
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a new ContextHandler wrapping the wrapped handler's
+// WithAttrs result.
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new ContextHandler wrapping the wrapped handler's
+// WithGroup result.
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{next: h.next.WithGroup(name)}
+}
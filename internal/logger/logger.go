@@ -3,12 +3,11 @@ package logger
 import (
 	"context"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
-	"time"
+	"strings"
 
-	"github.com/rs/zerolog"
-	"github.com/rs/zerolog/log"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -24,11 +23,12 @@ const (
 	RequestIDKey contextKey = "request_id"
 )
 
-var globalLogger zerolog.Logger
+var globalLogger = slog.Default()
 
 // Config holds logger configuration
 type Config struct {
 	Level      string
+	Format     string // "json" or "text"; defaults to "json"
 	LogDir     string
 	MaxSize    int  // megabytes
 	MaxBackups int  // number of backups
@@ -40,6 +40,7 @@ type Config struct {
 func DefaultConfig() Config {
 	return Config{
 		Level:      "info",
+		Format:     "json",
 		LogDir:     "logs",
 		MaxSize:    100,  // 100 MB
 		MaxBackups: 5,    // keep 5 backups
@@ -48,14 +49,10 @@ func DefaultConfig() Config {
 	}
 }
 
-// Setup initializes the global logger with file and console output
+// Setup initializes the global logger with file and console output. The
+// handler is wrapped in a ContextHandler so trace_id, request_id, and
+// user_id are attached automatically to every record carrying them in ctx.
 func Setup(config Config) error {
-	// Parse log level
-	lvl, err := zerolog.ParseLevel(config.Level)
-	if err != nil {
-		lvl = zerolog.InfoLevel
-	}
-
 	// Create logs directory if it doesn't exist
 	if err := os.MkdirAll(config.LogDir, 0755); err != nil {
 		return err
@@ -70,28 +67,13 @@ func Setup(config Config) error {
 		Compress:   config.Compress,
 	}
 
-	// Setup console output with pretty formatting for development
-	consoleWriter := zerolog.ConsoleWriter{
-		Out:        os.Stdout,
-		TimeFormat: time.RFC3339,
-	}
-
-	// Multi-writer: write to both file and console
-	multiWriter := io.MultiWriter(consoleWriter, fileLogger)
+	// Multi-writer: write to both file and stdout
+	multiWriter := io.MultiWriter(os.Stdout, fileLogger)
 
-	// Configure zerolog
-	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+	handler := NewContextHandler(newFormatHandler(config.Format, multiWriter, parseLevel(config.Level)))
 
-	// Create logger
-	globalLogger = zerolog.New(multiWriter).
-		Level(lvl).
-		With().
-		Timestamp().
-		Caller().
-		Logger()
-
-	// Set global logger
-	log.Logger = globalLogger
+	globalLogger = slog.New(handler)
+	slog.SetDefault(globalLogger)
 
 	return nil
 }
@@ -101,42 +83,47 @@ func SetupGlobal(level string) {
 	config := DefaultConfig()
 	config.Level = level
 	if err := Setup(config); err != nil {
-		log.Fatal().Err(err).Msg("Failed to setup logger")
+		slog.Default().Error("Failed to setup logger", "error", err)
+		os.Exit(1)
 	}
 }
 
-// New creates a new logger instance with the given level
-func New(level string) zerolog.Logger {
-	lvl, err := zerolog.ParseLevel(level)
-	if err != nil {
-		lvl = zerolog.InfoLevel
-	}
+// New creates a standalone JSON logger instance at the given level, writing
+// to stdout, without touching the global logger.
+func New(level string) *slog.Logger {
+	return slog.New(NewContextHandler(newFormatHandler("json", os.Stdout, parseLevel(level))))
+}
 
-	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
-	logger := zerolog.New(os.Stdout).Level(lvl).With().Timestamp().Caller().Logger()
-	return logger
+// L returns the configured global logger. Unlike FromContext, it does not
+// pre-bind any fields; pass ctx to one of its *Context methods (e.g.
+// InfoContext) and ContextHandler pulls trace_id/request_id/user_id from
+// ctx automatically.
+func L() *slog.Logger {
+	return globalLogger
 }
 
 // FromContext extracts the logger from context with all contextual fields
-func FromContext(ctx context.Context) *zerolog.Logger {
-	logger := globalLogger
+// pre-bound, so existing callers can keep writing
+// logger.FromContext(ctx).Info(...) without passing ctx a second time.
+func FromContext(ctx context.Context) *slog.Logger {
+	l := globalLogger
 
 	// Add trace ID if present
 	if traceID, ok := ctx.Value(TraceIDKey).(string); ok && traceID != "" {
-		logger = logger.With().Str("trace_id", traceID).Logger()
+		l = l.With("trace_id", traceID)
 	}
 
 	// Add request ID if present
 	if requestID, ok := ctx.Value(RequestIDKey).(string); ok && requestID != "" {
-		logger = logger.With().Str("request_id", requestID).Logger()
+		l = l.With("request_id", requestID)
 	}
 
 	// Add user ID if present
 	if userID, ok := ctx.Value(UserIDKey).(string); ok && userID != "" {
-		logger = logger.With().Str("user_id", userID).Logger()
+		l = l.With("user_id", userID)
 	}
 
-	return &logger
+	return l
 }
 
 // WithTraceID adds a trace ID to the context
@@ -177,3 +164,27 @@ func GetUserID(ctx context.Context) string {
 	}
 	return ""
 }
+
+// newFormatHandler builds a JSON or text slog.Handler writing to w at level.
+// Format defaults to JSON for anything other than "text".
+func newFormatHandler(format string, w io.Writer, level slog.Level) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if strings.EqualFold(format, "text") {
+		return slog.NewTextHandler(w, opts)
+	}
+	return slog.NewJSONHandler(w, opts)
+}
+
+// parseLevel maps a level name to a slog.Level, defaulting to info.
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
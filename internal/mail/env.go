@@ -0,0 +1,30 @@
+package mail
+
+import (
+	"os"
+	"strconv"
+)
+
+// NewFromEnv builds the Mailer used to deliver transactional email. It
+// relays through SMTP when SMTP_HOST is set, and otherwise falls back to
+// logging emails instead of sending them, so local development and tests
+// don't require a mail relay.
+func NewFromEnv() Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return NewLoggingMailer()
+	}
+
+	port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+	if err != nil {
+		port = 587
+	}
+
+	return NewSMTPMailer(SMTPConfig{
+		Host:     host,
+		Port:     port,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	})
+}
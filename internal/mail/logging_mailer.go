@@ -0,0 +1,25 @@
+package mail
+
+import (
+	"context"
+	"splitwise-clone/internal/logger"
+)
+
+// LoggingMailer logs emails instead of delivering them, for local
+// development and tests where no SMTP relay is configured.
+type LoggingMailer struct{}
+
+// NewLoggingMailer creates a new LoggingMailer instance
+func NewLoggingMailer() *LoggingMailer {
+	return &LoggingMailer{}
+}
+
+// Send logs the email's metadata and body instead of sending it
+func (m *LoggingMailer) Send(ctx context.Context, to, subject, htmlBody, textBody string) error {
+	logger.FromContext(ctx).Info("Email not sent (logging mailer)",
+		"to", to,
+		"subject", subject,
+		"text_body", textBody,
+	)
+	return nil
+}
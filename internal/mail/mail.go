@@ -0,0 +1,12 @@
+// Package mail sends transactional email (verification, password reset) via
+// a pluggable Mailer, so the auth service doesn't depend on a particular
+// delivery mechanism.
+package mail
+
+import "context"
+
+// Mailer sends a single email. Implementations decide how the message is
+// actually delivered; callers only ever see this interface.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, htmlBody, textBody string) error
+}
@@ -0,0 +1,70 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPConfig holds the settings needed to relay mail through an SMTP server.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer sends email via net/smtp, authenticating with PLAIN auth.
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer creates a new SMTPMailer instance
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send delivers a multipart/alternative email containing both htmlBody and
+// textBody. ctx is accepted to satisfy the Mailer interface; net/smtp has no
+// context support, so it is not otherwise consulted here.
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, htmlBody, textBody string) error {
+	addr := fmt.Sprintf("%s:%d", m.cfg.Host, m.cfg.Port)
+	auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+
+	msg := buildMIMEMessage(m.cfg.From, to, subject, htmlBody, textBody)
+
+	if err := smtp.SendMail(addr, auth, m.cfg.From, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("smtp: failed to send email to %s: %w", to, err)
+	}
+
+	return nil
+}
+
+// buildMIMEMessage assembles a minimal multipart/alternative RFC 822 message
+// carrying both a plain text and an HTML body.
+func buildMIMEMessage(from, to, subject, htmlBody, textBody string) string {
+	const boundary = "splitwise-clone-mail-boundary"
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	b.WriteString(textBody)
+	b.WriteString("\r\n\r\n")
+
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
+	b.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n\r\n")
+	b.WriteString(htmlBody)
+	b.WriteString("\r\n\r\n")
+
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var (
+	verifyEmailHTML   = template.Must(template.ParseFS(templateFS, "templates/verify_email.html.tmpl"))
+	verifyEmailText   = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/verify_email.txt.tmpl"))
+	passwordResetHTML = template.Must(template.ParseFS(templateFS, "templates/password_reset.html.tmpl"))
+	passwordResetText = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/password_reset.txt.tmpl"))
+)
+
+// verifyEmailData is the data available to the verification email templates.
+type verifyEmailData struct {
+	FirstName  string
+	ConfirmURL string
+	ExpiresAt  time.Time
+}
+
+// passwordResetData is the data available to the password reset email templates.
+type passwordResetData struct {
+	FirstName string
+	ResetURL  string
+	ExpiresAt time.Time
+}
+
+// RenderVerifyEmail renders the HTML and plain text bodies of the email
+// verification message.
+func RenderVerifyEmail(firstName, confirmURL string, expiresAt time.Time) (htmlBody, textBody string, err error) {
+	data := verifyEmailData{FirstName: firstName, ConfirmURL: confirmURL, ExpiresAt: expiresAt}
+	return renderPair(verifyEmailHTML, verifyEmailText, data)
+}
+
+// RenderPasswordReset renders the HTML and plain text bodies of the
+// password reset message.
+func RenderPasswordReset(firstName, resetURL string, expiresAt time.Time) (htmlBody, textBody string, err error) {
+	data := passwordResetData{FirstName: firstName, ResetURL: resetURL, ExpiresAt: expiresAt}
+	return renderPair(passwordResetHTML, passwordResetText, data)
+}
+
+func renderPair(html *template.Template, text *texttemplate.Template, data interface{}) (string, string, error) {
+	var htmlBuf, textBuf bytes.Buffer
+
+	if err := html.Execute(&htmlBuf, data); err != nil {
+		return "", "", fmt.Errorf("mail: failed to render html template: %w", err)
+	}
+	if err := text.Execute(&textBuf, data); err != nil {
+		return "", "", fmt.Errorf("mail: failed to render text template: %w", err)
+	}
+
+	return htmlBuf.String(), textBuf.String(), nil
+}
@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderVerifyEmail(t *testing.T) {
+	expiresAt := time.Date(2026, time.August, 1, 12, 0, 0, 0, time.UTC)
+
+	htmlBody, textBody, err := RenderVerifyEmail("Jane", "https://app.example.com/verify?token=abc123", expiresAt)
+
+	require.NoError(t, err)
+	assert.Contains(t, htmlBody, "Hi Jane,")
+	assert.Contains(t, htmlBody, "https://app.example.com/verify?token=abc123")
+	assert.Contains(t, textBody, "Hi Jane,")
+	assert.Contains(t, textBody, "https://app.example.com/verify?token=abc123")
+	assert.Contains(t, textBody, expiresAt.String())
+}
+
+func TestRenderVerifyEmail_EscapesHTMLInFirstName(t *testing.T) {
+	htmlBody, textBody, err := RenderVerifyEmail("<script>alert(1)</script>", "https://app.example.com/verify", time.Now())
+
+	require.NoError(t, err)
+	assert.NotContains(t, htmlBody, "<script>alert(1)</script>")
+	assert.True(t, strings.Contains(htmlBody, "&lt;script&gt;"))
+	// The text template has no HTML to escape, so the raw name passes through.
+	assert.Contains(t, textBody, "<script>alert(1)</script>")
+}
+
+func TestRenderPasswordReset(t *testing.T) {
+	expiresAt := time.Date(2026, time.August, 1, 12, 0, 0, 0, time.UTC)
+
+	htmlBody, textBody, err := RenderPasswordReset("John", "https://app.example.com/reset?token=xyz789", expiresAt)
+
+	require.NoError(t, err)
+	assert.Contains(t, htmlBody, "Hi John,")
+	assert.Contains(t, htmlBody, "https://app.example.com/reset?token=xyz789")
+	assert.Contains(t, textBody, "Hi John,")
+	assert.Contains(t, textBody, "https://app.example.com/reset?token=xyz789")
+	assert.Contains(t, textBody, expiresAt.String())
+}
+
+func TestRenderPasswordReset_EscapesHTMLInFirstName(t *testing.T) {
+	htmlBody, _, err := RenderPasswordReset("<b>Mallory</b>", "https://app.example.com/reset", time.Now())
+
+	require.NoError(t, err)
+	assert.NotContains(t, htmlBody, "<b>Mallory</b>")
+	assert.Contains(t, htmlBody, "&lt;b&gt;")
+}
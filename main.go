@@ -2,22 +2,74 @@ package main
 
 import (
 	"context"
-	"splitwise-clone/db"
-	"splitwise-clone/logger"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/rs/zerolog/log"
+	"splitwise-clone/internal/app"
+	"splitwise-clone/internal/config"
+	"splitwise-clone/internal/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func main() {
 	logger.SetupGlobal("info")
-	log.Info().Msg("Application started")
+	log := logger.L()
+	log.Info("Application started")
+
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		log.Error("Failed to load config", "error", err)
+		os.Exit(1)
+	}
 
 	ctx := context.Background()
-	pool, err := db.NewPool(ctx)
+	pool, err := pgxpool.New(ctx, os.Getenv("DATABASE_URL"))
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create database pool")
+		log.Error("Failed to create database pool", "error", err)
+		os.Exit(1)
 	}
 	defer pool.Close()
 
-	log.Info().Msg("Database pool created successfully")
+	log.Info("Database pool created successfully")
+
+	httpServer := app.NewHTTPServer(cfg, pool)
+	grpcServer := app.NewGRPCServer(cfg, pool)
+
+	go func() {
+		if err := httpServer.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("HTTP server stopped unexpectedly", "error", err)
+		}
+	}()
+
+	go func() {
+		if err := grpcServer.Start(); err != nil {
+			log.Error("gRPC server stopped unexpectedly", "error", err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	log.Info("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Error("Failed to shut down HTTP server cleanly", "error", err)
+	}
+	if err := grpcServer.Shutdown(shutdownCtx); err != nil {
+		log.Error("Failed to shut down gRPC server cleanly", "error", err)
+	}
 }